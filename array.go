@@ -0,0 +1,274 @@
+package uuid
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var _ driver.Valuer = UUIDs{}
+var _ sql.Scanner = (*UUIDs)(nil)
+var _ driver.Valuer = NullUUIDs{}
+var _ sql.Scanner = (*NullUUIDs)(nil)
+
+// uuidOID is the PostgreSQL type OID of uuid.
+const uuidOID = 2950
+
+// UUIDs is a slice of UUID that maps to a PostgreSQL uuid[] column.
+// It scans both the text array literal ({a,b,c}) and the binary array
+// format, and is valued as a text array literal, so it can be used directly
+// for parameters such as `WHERE id = ANY($1)`.
+type UUIDs []UUID
+
+// NullUUIDs is like UUIDs but allows NULL elements.
+type NullUUIDs []NullUUID
+
+// Value implements the driver.Valuer interface.
+// A nil slice is valued as NULL; an empty slice as '{}'.
+func (a UUIDs) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	b := make([]byte, 0, 2+len(a)*37)
+	b = append(b, '{')
+	for i, u := range a {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = b[:len(b)+36]
+		encodeCanonical(b[len(b)-36:], u)
+	}
+	b = append(b, '}')
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (a *UUIDs) Scan(src any) error {
+	if src == nil {
+		*a = nil
+		return nil
+	}
+	out := UUIDs{}
+	err := scanArray(src, "UUIDs", func(elem []byte, null, text bool) error {
+		if null {
+			return errors.New("uuid: cannot scan NULL element into UUIDs, use NullUUIDs")
+		}
+		var u UUID
+		if err := decodeArrayElem(elem, text, &u); err != nil {
+			return err
+		}
+		out = append(out, u)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*a = out
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// A nil slice is valued as NULL; an empty slice as '{}'.
+func (a NullUUIDs) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	b := make([]byte, 0, 2+len(a)*37)
+	b = append(b, '{')
+	for i, u := range a {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		if !u.Valid {
+			b = append(b, "NULL"...)
+			continue
+		}
+		b = b[:len(b)+36]
+		encodeCanonical(b[len(b)-36:], u.UUID)
+	}
+	b = append(b, '}')
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (a *NullUUIDs) Scan(src any) error {
+	if src == nil {
+		*a = nil
+		return nil
+	}
+	out := NullUUIDs{}
+	err := scanArray(src, "NullUUIDs", func(elem []byte, null, text bool) error {
+		if null {
+			out = append(out, NullUUID{})
+			return nil
+		}
+		var u UUID
+		if err := decodeArrayElem(elem, text, &u); err != nil {
+			return err
+		}
+		out = append(out, NullUUID{UUID: u, Valid: true})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*a = out
+	return nil
+}
+
+// AppendPgBinary appends the PostgreSQL binary array encoding of a to dst,
+// for drivers and protocols that transfer uuid[] in binary format.
+func (a UUIDs) AppendPgBinary(dst []byte) []byte {
+	dst = appendBinaryArrayHeader(dst, len(a), false)
+	for _, u := range a {
+		dst = binary.BigEndian.AppendUint32(dst, 16)
+		dst = append(dst, u[:]...)
+	}
+	return dst
+}
+
+// AppendPgBinary appends the PostgreSQL binary array encoding of a to dst,
+// for drivers and protocols that transfer uuid[] in binary format.
+func (a NullUUIDs) AppendPgBinary(dst []byte) []byte {
+	hasNull := false
+	for _, u := range a {
+		if !u.Valid {
+			hasNull = true
+			break
+		}
+	}
+	dst = appendBinaryArrayHeader(dst, len(a), hasNull)
+	for _, u := range a {
+		if !u.Valid {
+			dst = binary.BigEndian.AppendUint32(dst, 0xFFFFFFFF)
+			continue
+		}
+		dst = binary.BigEndian.AppendUint32(dst, 16)
+		dst = append(dst, u.UUID[:]...)
+	}
+	return dst
+}
+
+func appendBinaryArrayHeader(dst []byte, n int, hasNull bool) []byte {
+	if n == 0 {
+		dst = binary.BigEndian.AppendUint32(dst, 0) // ndim
+		dst = binary.BigEndian.AppendUint32(dst, 0) // flags
+		return binary.BigEndian.AppendUint32(dst, uuidOID)
+	}
+	var flags uint32
+	if hasNull {
+		flags = 1
+	}
+	dst = binary.BigEndian.AppendUint32(dst, 1) // ndim
+	dst = binary.BigEndian.AppendUint32(dst, flags)
+	dst = binary.BigEndian.AppendUint32(dst, uuidOID)
+	dst = binary.BigEndian.AppendUint32(dst, uint32(n))
+	return binary.BigEndian.AppendUint32(dst, 1) // lower bound
+}
+
+func decodeArrayElem(elem []byte, text bool, u *UUID) error {
+	if text {
+		return parse(elem, u)
+	}
+	return u.UnmarshalBinary(elem)
+}
+
+// scanArray walks the elements of a one-dimensional PostgreSQL array given
+// in either text or binary format, calling fn for each of them. The target
+// names the destination type in conversion errors.
+func scanArray(src any, target string, fn func(elem []byte, null, text bool) error) error {
+	var b []byte
+	switch src := src.(type) {
+	case []byte:
+		b = src
+	case string:
+		b = []byte(src)
+	default:
+		return fmt.Errorf("uuid: cannot convert %T to %s", src, target)
+	}
+
+	if len(b) > 0 && b[0] == '{' {
+		return scanTextArray(b, fn)
+	}
+	return scanBinaryArray(b, fn)
+}
+
+// scanTextArray parses the PostgreSQL array literal, e.g.
+//
+//	{6ba7b810-9dad-11d1-80b4-00c04fd430c8,"6ba7b811-9dad-11d1-80b4-00c04fd430c8",NULL}
+func scanTextArray(b []byte, fn func(elem []byte, null, text bool) error) error {
+	if len(b) < 2 || b[len(b)-1] != '}' {
+		return fmt.Errorf("uuid: invalid array literal %q", b)
+	}
+	body := b[1 : len(b)-1]
+	if len(body) == 0 {
+		return nil
+	}
+	if body[0] == '{' {
+		return fmt.Errorf("uuid: multi-dimensional arrays are not supported: %q", b)
+	}
+
+	for elem := range bytes.SplitSeq(body, []byte{','}) {
+		elem = bytes.TrimSpace(elem)
+		quoted := len(elem) >= 2 && elem[0] == '"' && elem[len(elem)-1] == '"'
+		if quoted {
+			elem = elem[1 : len(elem)-1]
+		}
+		if err := fn(elem, !quoted && bytes.EqualFold(elem, []byte("NULL")), true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanBinaryArray parses the PostgreSQL binary array format:
+//
+//	int32 ndim, int32 flags, uint32 element OID,
+//	ndim * (int32 length, int32 lower bound),
+//	elements * (int32 length or -1 for NULL, data)
+func scanBinaryArray(b []byte, fn func(elem []byte, null, text bool) error) error {
+	if len(b) < 12 {
+		return fmt.Errorf("uuid: binary array header too short, got %d bytes", len(b))
+	}
+	ndim := int32(binary.BigEndian.Uint32(b[0:4]))
+	oid := binary.BigEndian.Uint32(b[8:12])
+	b = b[12:]
+
+	switch {
+	case ndim == 0:
+		return nil
+	case ndim != 1:
+		return fmt.Errorf("uuid: multi-dimensional arrays are not supported, got %d dimensions", ndim)
+	case oid != uuidOID:
+		return fmt.Errorf("uuid: binary array is not a uuid[], got element OID %d", oid)
+	case len(b) < 8:
+		return errors.New("uuid: binary array dimension truncated")
+	}
+	n := int32(binary.BigEndian.Uint32(b[0:4]))
+	b = b[8:]
+
+	for range n {
+		if len(b) < 4 {
+			return errors.New("uuid: binary array element truncated")
+		}
+		size := int32(binary.BigEndian.Uint32(b[0:4]))
+		b = b[4:]
+		if size < 0 {
+			if err := fn(nil, true, false); err != nil {
+				return err
+			}
+			continue
+		}
+		if int(size) > len(b) {
+			return errors.New("uuid: binary array element truncated")
+		}
+		if err := fn(b[:size], false, false); err != nil {
+			return err
+		}
+		b = b[size:]
+	}
+	return nil
+}
@@ -1,7 +1,8 @@
 package uuid
 
 import (
-	"errors"
+	"bytes"
+	"encoding/json"
 	"fmt"
 )
 
@@ -28,7 +29,7 @@ func (u UUID) MarshalBinary() ([]byte, error) {
 // It will return an error if the slice isn't 16 bytes long.
 func (u *UUID) UnmarshalBinary(data []byte) error {
 	if len(data) != 16 {
-		return fmt.Errorf("%s, got %d bytes", "uuid: UUID must be exactly 16 bytes long", len(data))
+		return fmt.Errorf("%w: UUID must be exactly 16 bytes long, got %d bytes", ErrInvalidLength, len(data))
 	}
 	copy(u[:], data)
 
@@ -55,73 +56,49 @@ func fromHexChar(c byte) byte {
 	return 255
 }
 
-// Parse parses UUID text representation from a byte slice and populates
+// parse parses UUID text representation from a byte slice and populates
 // the provided UUID reference. It centralizes the parsing logic so that both
 // Parse (string input) and UnmarshalText ([]byte input) can delegate to this
 // single implementation, eliminating code duplication.
 //
 // Supported formats and ABNF grammar are documented on UnmarshalText; refer
-// there for full details. This helper simply enforces those rules.
+// there for full details. The rules are enforced by the zero Parser.
 func parse(b []byte, u *UUID) error {
-	// Fast-path: ensure we don't accidentally mutate the caller's slice.
-	// We will only reslice, never modify the underlying bytes.
-	switch len(b) {
-	case 32: // hash
-	case 36: // canonical
-	case 34, 38:
-		if b[0] != '{' || b[len(b)-1] != '}' {
-			return fmt.Errorf("%s %q", "uuid: incorrect UUID format in string", b)
-		}
-		b = b[1 : len(b)-1]
-	case 41, 45:
-		if string(b[:9]) != "urn:uuid:" {
-			return fmt.Errorf("%s %q", "uuid: incorrect UUID format in string", b[:9])
-		}
-		b = b[9:]
-	default:
-		return fmt.Errorf("%s %d in string %q", "uuid: UUID must be exactly 16 bytes long", len(b), b)
-	}
-
-	// canonical (36 chars with dashes at fixed positions)
-	if len(b) == 36 {
-		if b[8] != '-' || b[13] != '-' || b[18] != '-' || b[23] != '-' {
-			return fmt.Errorf("%s %q", "uuid: incorrect UUID format in string", b)
-		}
-		for i, x := range [16]byte{
-			0, 2, 4, 6,
-			9, 11,
-			14, 16,
-			19, 21,
-			24, 26, 28, 30, 32, 34,
-		} {
-			v1 := fromHexChar(b[x])
-			v2 := fromHexChar(b[x+1])
-			if v1|v2 == 255 {
-				return errors.New("uuid: invalid UUID format")
-			}
-			u[i] = (v1 << 4) | v2
-		}
-		return nil
-	}
+	return Parser{}.parse(b, u)
+}
 
-	// hash-like (32 hex chars, no dashes)
-	for i := 0; i < 32; i += 2 {
-		v1 := fromHexChar(b[i])
-		v2 := fromHexChar(b[i+1])
-		if v1|v2 == 255 {
-			return errors.New("uuid: invalid UUID format")
-		}
-		u[i/2] = (v1 << 4) | v2
+// parseInto is like parse but leaves u unchanged on error, as the
+// Unmarshal methods promise.
+func parseInto(b []byte, u *UUID) error {
+	var uu UUID
+	if err := parse(b, &uu); err != nil {
+		return err
 	}
+	*u = uu
 	return nil
 }
 
 // Parse parses the UUID stored in the string text. Parsing and supported
-// formats are the same as UnmarshalText.
+// formats are the same as UnmarshalText. Parse does not allocate unless it
+// returns an error.
 func Parse(s string) (UUID, error) {
-	u := UUID{}
-	err := parse([]byte(s), &u)
-	return u, err
+	return Parser{}.Parse(s)
+}
+
+// ParseBytes is like Parse but takes a byte slice. Parsing and supported
+// formats are the same as UnmarshalText.
+func ParseBytes(b []byte) (UUID, error) {
+	return Parser{}.ParseBytes(b)
+}
+
+// MustParse is like Parse but panics if the string cannot be parsed.
+// It simplifies safe initialization of global variables holding UUIDs.
+func MustParse(s string) UUID {
+	u, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
 }
 
 // MarshalText implements the encoding.TextMarshaler interface.
@@ -130,6 +107,38 @@ func (u UUID) MarshalText() ([]byte, error) {
 	return []byte(u.String()), nil
 }
 
+// MarshalJSON implements the json.Marshaler interface.
+// The UUID is encoded as a JSON string in canonical form.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 38)
+	b[0], b[37] = '"', '"'
+	encodeCanonical(b[1:37], u)
+	return b, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts a JSON string in any of the formats supported by UnmarshalText.
+// A JSON null is a no-op, matching the behaviour of encoding/json.
+// On error u is left unchanged.
+func (u *UUID) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	n := len(b)
+	if n < 2 || b[0] != '"' || b[n-1] != '"' {
+		return fmt.Errorf("%w: cannot unmarshal non-string JSON value %q", ErrInvalidFormat, b)
+	}
+	if bytes.IndexByte(b, '\\') >= 0 {
+		// Escaped strings are rare for UUIDs; only unquote when needed.
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		return parseInto([]byte(s), u)
+	}
+	return parseInto(b[1:n-1], u)
+}
+
 // Following formats are supported:
 //
 //	"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
@@ -164,6 +173,7 @@ func (u UUID) MarshalText() ([]byte, error) {
 //	uuid      := canonical | hashlike | braced | urn
 //
 // The function delegates validation to internal parseBytes().
+// On error u is left unchanged.
 func (u *UUID) UnmarshalText(b []byte) error {
-	return parse(b, u)
+	return parseInto(b, u)
 }
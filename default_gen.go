@@ -196,6 +196,28 @@ func (g *gen) NewV7Lazy() (UUID, error) {
 	return u, nil
 }
 
+func (g *gen) NewV8SQLServer() (UUID, error) {
+	u := UUID{}
+
+	if err := g.fill(u[:10]); err != nil {
+		return NilUUID, err
+	}
+
+	// Bytes 10-15 are the most significant under SQL Server ordering.
+	ms := uint64(time.Now().UnixMilli())
+	u[10] = byte(ms >> 40)
+	u[11] = byte(ms >> 32)
+	u[12] = byte(ms >> 24)
+	u[13] = byte(ms >> 16)
+	u[14] = byte(ms >> 8)
+	u[15] = byte(ms)
+
+	u.SetVersion(V8)
+	u.SetVariant(VariantRFC9562)
+
+	return u, nil
+}
+
 func (g *gen) NewV4() (UUID, error) {
 	// https://datatracker.ietf.org/doc/html/rfc9562#name-uuid-version-7
 	//
@@ -0,0 +1,135 @@
+package uuid
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxVarint128Len is the maximum length of a 128-bit LEB128 varint.
+const maxVarint128Len = 19
+
+// ErrUnsorted is returned by DeltaEncoder when UUIDs are not written in
+// increasing Compare order.
+var ErrUnsorted = errors.New("uuid: delta encoding requires sorted UUIDs")
+
+// appendVarint128 appends u, taken as a 128-bit integer, in LEB128 form.
+func appendVarint128(b []byte, u UUID) []byte {
+	hi, lo := u.Uint128()
+	for hi != 0 || lo >= 0x80 {
+		b = append(b, byte(lo)|0x80)
+		lo = lo>>7 | hi<<57
+		hi >>= 7
+	}
+	return append(b, byte(lo))
+}
+
+// DeltaEncoder writes a sorted sequence of UUIDs as the LEB128 varints of
+// the differences between consecutive values, taken as 128-bit integers.
+// Sorted V7 UUIDs share their timestamp prefix, so most deltas need far
+// fewer than 16 bytes. Call Flush after the last UUID.
+type DeltaEncoder struct {
+	w    *bufio.Writer
+	prev UUID
+	buf  [maxVarint128Len]byte
+}
+
+// NewDeltaEncoder returns a DeltaEncoder writing to w.
+func NewDeltaEncoder(w io.Writer) *DeltaEncoder {
+	return &DeltaEncoder{w: bufio.NewWriter(w)}
+}
+
+// Encode writes u. It returns ErrUnsorted if u sorts before the previous
+// UUID; duplicates are allowed.
+func (e *DeltaEncoder) Encode(u UUID) error {
+	if u.Compare(e.prev) < 0 {
+		return fmt.Errorf("%w: %s after %s", ErrUnsorted, u, e.prev)
+	}
+	_, err := e.w.Write(appendVarint128(e.buf[:0], u.sub128(e.prev)))
+	e.prev = u
+	return err
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (e *DeltaEncoder) Flush() error {
+	return e.w.Flush()
+}
+
+// DeltaDecoder reads UUIDs written by a DeltaEncoder.
+type DeltaDecoder struct {
+	r    io.ByteReader
+	prev UUID
+}
+
+// NewDeltaDecoder returns a DeltaDecoder reading from r. If r does not
+// implement io.ByteReader it is buffered.
+func NewDeltaDecoder(r io.Reader) *DeltaDecoder {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &DeltaDecoder{r: br}
+}
+
+// Decode returns the next UUID. It returns io.EOF at the end of a
+// well-formed stream and io.ErrUnexpectedEOF inside a truncated varint.
+func (d *DeltaDecoder) Decode() (UUID, error) {
+	var hi, lo uint64
+	for i := 0; ; i++ {
+		c, err := d.r.ReadByte()
+		if err != nil {
+			if err == io.EOF && i > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return NilUUID, err
+		}
+		if i == maxVarint128Len-1 && c > 0x03 {
+			return NilUUID, errors.New("uuid: delta varint overflows 128 bits")
+		}
+
+		shift := uint(7 * i)
+		v := uint64(c & 0x7F)
+		switch {
+		case shift < 64:
+			lo |= v << shift
+			if shift > 57 {
+				hi |= v >> (64 - shift)
+			}
+		default:
+			hi |= v << (shift - 64)
+		}
+		if c < 0x80 {
+			break
+		}
+	}
+	d.prev = d.prev.add128(FromUint128(hi, lo))
+	return d.prev, nil
+}
+
+// EncodeSorted writes the sorted UUIDs of ids to w with a DeltaEncoder.
+func EncodeSorted(w io.Writer, ids []UUID) error {
+	e := NewDeltaEncoder(w)
+	for _, u := range ids {
+		if err := e.Encode(u); err != nil {
+			return err
+		}
+	}
+	return e.Flush()
+}
+
+// DecodeSorted reads every UUID from r with a DeltaDecoder.
+func DecodeSorted(r io.Reader) ([]UUID, error) {
+	d := NewDeltaDecoder(r)
+	var ids []UUID
+	for {
+		u, err := d.Decode()
+		if err == io.EOF {
+			return ids, nil
+		}
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, u)
+	}
+}
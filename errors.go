@@ -0,0 +1,69 @@
+package uuid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors reported by parsing and decoding. Use errors.Is to test
+// for them; parse failures carry more detail in a *ParseError.
+var (
+	// ErrInvalidLength is reported when the input has a length that matches
+	// none of the supported forms.
+	ErrInvalidLength = errors.New("uuid: invalid length")
+
+	// ErrInvalidFormat is reported when the input has a valid length but
+	// misplaced separators, braces or URN prefix.
+	ErrInvalidFormat = errors.New("uuid: invalid format")
+
+	// ErrInvalidChar is reported when a hex digit is expected but another
+	// character is found.
+	ErrInvalidChar = errors.New("uuid: invalid character")
+
+	// ErrInvalidVersion is reported when the version field holds a value
+	// that is not accepted.
+	ErrInvalidVersion = errors.New("uuid: invalid version")
+
+	// ErrInvalidVariant is reported when the variant bits are not
+	// VariantRFC9562.
+	ErrInvalidVariant = errors.New("uuid: invalid variant")
+
+	// ErrFutureTimestamp is reported when the embedded timestamp lies too
+	// far in the future.
+	ErrFutureTimestamp = errors.New("uuid: timestamp in the future")
+)
+
+// maxErrorInput bounds how much of the input is quoted in error messages.
+const maxErrorInput = 64
+
+// ParseError describes a failure to parse the text form of a UUID.
+type ParseError struct {
+	Input    string // input text, as given
+	Offset   int    // byte offset of the offending character in Input, or -1
+	Expected string // description of what was expected at Offset
+	Err      error  // one of the sentinel errors, for errors.Is
+}
+
+func (e *ParseError) Error() string {
+	in := e.Input
+	if len(in) > maxErrorInput {
+		in = in[:maxErrorInput] + "..."
+	}
+	if e.Offset < 0 {
+		return fmt.Sprintf("%v %q: expected %s", e.Err, in, e.Expected)
+	}
+	return fmt.Sprintf("%v at offset %d in %q: expected %s", e.Err, e.Offset, in, e.Expected)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+func newParseError(input []byte, offset int, expected string, err error) *ParseError {
+	return &ParseError{
+		Input:    string(input),
+		Offset:   offset,
+		Expected: expected,
+		Err:      err,
+	}
+}
@@ -0,0 +1,95 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+var _ driver.Valuer = GUID{}
+var _ sql.Scanner = (*GUID)(nil)
+
+// swapGUID converts between the RFC-9562 big-endian byte order and the
+// Microsoft GUID mixed-endian layout. The conversion is its own inverse:
+// the first three fields (4, 2 and 2 bytes) are byte-swapped and the
+// remaining 8 bytes are copied as-is.
+func swapGUID(dst, src []byte) {
+	_ = src[15]
+	_ = dst[15]
+	dst[0], dst[1], dst[2], dst[3] = src[3], src[2], src[1], src[0]
+	dst[4], dst[5] = src[5], src[4]
+	dst[6], dst[7] = src[7], src[6]
+	copy(dst[8:16], src[8:16])
+}
+
+// FromGUIDBytes returns the UUID encoded in the Microsoft GUID byte layout,
+// as produced by .NET Guid.ToByteArray, SQL Server uniqueidentifier and
+// Windows registry blobs, where the first three fields are little-endian.
+// It will return an error if the slice isn't 16 bytes long.
+func FromGUIDBytes(b []byte) (UUID, error) {
+	var u UUID
+	if len(b) != 16 {
+		return u, fmt.Errorf("%w: GUID must be exactly 16 bytes long, got %d bytes", ErrInvalidLength, len(b))
+	}
+	swapGUID(u[:], b)
+	return u, nil
+}
+
+// ToGUIDBytes returns a newly allocated byte slice containing the UUID in
+// the Microsoft GUID mixed-endian layout. It is the inverse of FromGUIDBytes.
+func (u UUID) ToGUIDBytes() []byte {
+	b := make([]byte, 16)
+	swapGUID(b, u[:])
+	return b
+}
+
+// GUID is a UUID that is exchanged with the database in the Microsoft GUID
+// mixed-endian binary layout, as used by SQL Server uniqueidentifier columns.
+// Its string form is the same as the equivalent UUID.
+type GUID UUID
+
+// String returns the canonical string form of the GUID.
+func (g GUID) String() string {
+	return UUID(g).String()
+}
+
+// Value implements the driver.Valuer interface.
+// The GUID is emitted as 16 bytes in mixed-endian layout.
+func (g GUID) Value() (driver.Value, error) {
+	return UUID(g).ToGUIDBytes(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// A 16-byte slice or array is interpreted in the mixed-endian GUID layout,
+// while a string or a longer byte slice is parsed as UUID text.
+func (g *GUID) Scan(src any) error {
+	if b, ok := rawBinary(src); ok {
+		u, err := FromGUIDBytes(b)
+		if err != nil {
+			return err
+		}
+		*g = GUID(u)
+		return nil
+	}
+	return (*UUID)(g).Scan(src)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (g GUID) MarshalText() ([]byte, error) {
+	return UUID(g).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (g *GUID) UnmarshalText(b []byte) error {
+	return (*UUID)(g).UnmarshalText(b)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (g GUID) MarshalJSON() ([]byte, error) {
+	return UUID(g).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (g *GUID) UnmarshalJSON(b []byte) error {
+	return (*UUID)(g).UnmarshalJSON(b)
+}
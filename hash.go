@@ -0,0 +1,119 @@
+package uuid
+
+import (
+	"hash/fnv"
+	"slices"
+)
+
+// fmix64 is the 64-bit finalizer of MurmurHash3; it spreads every input
+// bit over the whole output.
+func fmix64(k uint64) uint64 {
+	k ^= k >> 33
+	k *= 0xff51afd7ed558ccd
+	k ^= k >> 33
+	k *= 0xc4ceb9fe1a85ec53
+	k ^= k >> 33
+	return k
+}
+
+// Hash64 returns a well-distributed 64-bit hash of u, derived from the bits
+// that are random for the UUID's version. For V7 the timestamp is skipped,
+// so placement doesn't cluster by creation time; for V4 the version and
+// variant bits are skipped. Other versions hash all 128 bits.
+//
+// Hash64 is stable across processes and releases, so it can be persisted.
+func (u UUID) Hash64() uint64 {
+	hi, lo := u.Uint128()
+	if u.Variant() == VariantRFC9562 {
+		switch u.Version() {
+		case V4:
+			// 60 random bits in hi, 62 in lo.
+			return fmix64(lo&^(3<<62) ^ fmix64(hi&^0xF000))
+		case V7:
+			// rand_a (12 bits) and rand_b (62 bits).
+			return fmix64(lo&^(3<<62) ^ (hi&0x0FFF)<<50)
+		}
+	}
+	return fmix64(lo ^ fmix64(hi))
+}
+
+// Shard maps u to a shard in [0, n) using jump consistent hashing on
+// Hash64: placement is uniform for every UUID version, and growing n
+// moves only 1/n of the keys. It panics if n <= 0.
+func (u UUID) Shard(n int) int {
+	if n <= 0 {
+		panic("uuid: Shard called with n <= 0")
+	}
+	// Lamping and Veach, "A Fast, Minimal Memory, Consistent Hash Algorithm".
+	key := u.Hash64()
+	var b, j int64 = -1, 0
+	for j < int64(n) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
+
+// Rendezvous selects a node for each UUID by rendezvous (highest random
+// weight) hashing. Unlike Shard, nodes are named and any of them can be
+// removed; only the keys owned by a removed node move.
+//
+// A Rendezvous is not safe for concurrent mutation.
+type Rendezvous struct {
+	nodes  []string
+	hashes []uint64
+}
+
+// NewRendezvous returns a Rendezvous over the given nodes.
+func NewRendezvous(nodes ...string) *Rendezvous {
+	r := &Rendezvous{}
+	for _, node := range nodes {
+		r.Add(node)
+	}
+	return r
+}
+
+func nodeHash(node string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(node))
+	return h.Sum64()
+}
+
+// Add adds node if it is not present yet.
+func (r *Rendezvous) Add(node string) {
+	if slices.Contains(r.nodes, node) {
+		return
+	}
+	r.nodes = append(r.nodes, node)
+	r.hashes = append(r.hashes, nodeHash(node))
+}
+
+// Remove removes node if it is present.
+func (r *Rendezvous) Remove(node string) {
+	if i := slices.Index(r.nodes, node); i >= 0 {
+		r.nodes = slices.Delete(r.nodes, i, i+1)
+		r.hashes = slices.Delete(r.hashes, i, i+1)
+	}
+}
+
+// Nodes returns a copy of the nodes, in insertion order.
+func (r *Rendezvous) Nodes() []string {
+	return slices.Clone(r.nodes)
+}
+
+// Lookup returns the node that owns u, or "" if there are no nodes.
+func (r *Rendezvous) Lookup(u UUID) string {
+	key := u.Hash64()
+	best, bestScore := -1, uint64(0)
+	for i, node := range r.nodes {
+		score := fmix64(key ^ r.hashes[i])
+		if best < 0 || score > bestScore || (score == bestScore && node < r.nodes[best]) {
+			best, bestScore = i, score
+		}
+	}
+	if best < 0 {
+		return ""
+	}
+	return r.nodes[best]
+}
@@ -0,0 +1,121 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+)
+
+// SWAR (SIMD within a register) helpers that decode eight hex characters at
+// a time in a uint64. They are portable Go, so every architecture shares the
+// same code path. Encoding stays on the hexTable lookup used by Encode,
+// which benchmarks faster than the equivalent SWAR arithmetic.
+
+const (
+	swarOnes = 0x0101010101010101
+	swarHigh = 0x8080808080808080
+)
+
+// swarInRange returns the high bit of each byte of x set iff that byte is in
+// [lo, hi]. Every byte of x must be below 0x80, so no sum carries into the
+// next byte.
+func swarInRange(x uint64, lo, hi byte) uint64 {
+	return (x + swarOnes*uint64(0x80-lo)) &^ (x + swarOnes*uint64(0x7F-hi)) & swarHigh
+}
+
+// decodeHex8 decodes the eight hex characters packed little-endian in x
+// into four bytes, returned little-endian. It reports false if any
+// character is not a hex digit or violates the case rule c.
+func decodeHex8(x uint64, c Case) (uint32, bool) {
+	if x&swarHigh != 0 {
+		return 0, false
+	}
+	digit := swarInRange(x, '0', '9')
+	alpha := swarInRange(x|swarOnes*0x20, 'a', 'f')
+	if digit|alpha != swarHigh {
+		return 0, false
+	}
+	switch c {
+	case LowerCase:
+		if alpha&^(x<<2) != 0 { // bit 5 clear: upper-case letter
+			return 0, false
+		}
+	case UpperCase:
+		if alpha&(x<<2) != 0 { // bit 5 set: lower-case letter
+			return 0, false
+		}
+	}
+
+	// Nibble values: low four bits, plus 9 for letters.
+	v := x&(swarOnes*0x0F) + (alpha>>7)*9
+
+	// Merge nibble pairs into bytes, then pack the four bytes together.
+	v = (v<<4 | v>>8) & 0x00FF00FF00FF00FF
+	v = (v | v>>8) & 0x0000FFFF0000FFFF
+	v = (v | v>>16) & 0xFFFFFFFF
+	return uint32(v), true
+}
+
+// decodeCanonical decodes the 36-character canonical form in b, whose dashes
+// have already been checked. It reports false on any invalid character.
+func decodeCanonical(b []byte, u *UUID, c Case) bool {
+	_ = b[35]
+	w0, ok0 := decodeHex8(binary.LittleEndian.Uint64(b[0:8]), c)
+	w1, ok1 := decodeHex8(uint64(binary.LittleEndian.Uint32(b[9:13]))|uint64(binary.LittleEndian.Uint32(b[14:18]))<<32, c)
+	w2, ok2 := decodeHex8(uint64(binary.LittleEndian.Uint32(b[19:23]))|uint64(binary.LittleEndian.Uint32(b[24:28]))<<32, c)
+	w3, ok3 := decodeHex8(binary.LittleEndian.Uint64(b[28:36]), c)
+	if !(ok0 && ok1 && ok2 && ok3) {
+		return false
+	}
+	binary.LittleEndian.PutUint32(u[0:4], w0)
+	binary.LittleEndian.PutUint32(u[4:8], w1)
+	binary.LittleEndian.PutUint32(u[8:12], w2)
+	binary.LittleEndian.PutUint32(u[12:16], w3)
+	return true
+}
+
+// decodeHash decodes the 32-character hash-like form in b.
+// It reports false on any invalid character.
+func decodeHash(b []byte, u *UUID, c Case) bool {
+	_ = b[31]
+	w0, ok0 := decodeHex8(binary.LittleEndian.Uint64(b[0:8]), c)
+	w1, ok1 := decodeHex8(binary.LittleEndian.Uint64(b[8:16]), c)
+	w2, ok2 := decodeHex8(binary.LittleEndian.Uint64(b[16:24]), c)
+	w3, ok3 := decodeHex8(binary.LittleEndian.Uint64(b[24:32]), c)
+	if !(ok0 && ok1 && ok2 && ok3) {
+		return false
+	}
+	binary.LittleEndian.PutUint32(u[0:4], w0)
+	binary.LittleEndian.PutUint32(u[4:8], w1)
+	binary.LittleEndian.PutUint32(u[8:12], w2)
+	binary.LittleEndian.PutUint32(u[12:16], w3)
+	return true
+}
+
+// ParseMany parses each string of src, as Parse does, and appends the
+// results to dst. On failure it returns the UUIDs parsed so far and an error
+// wrapping the *ParseError with the index of the offending element.
+func ParseMany(dst []UUID, src []string) ([]UUID, error) {
+	n := len(dst)
+	dst = slices.Grow(dst, len(src))[:n+len(src)]
+	for i, s := range src {
+		if err := parse(stringBytes(s), &dst[n+i]); err != nil {
+			return dst[:n+i], fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	return dst, nil
+}
+
+// EncodeMany serializes each UUID of src in canonical form into
+// consecutive 36-byte slots of buf and returns buf[:36*len(src)].
+//
+// WARNING: Like Encode, this function does NOT perform length checks on the
+// provided buffer. The caller MUST ensure that len(buf) >= 36*len(src).
+func EncodeMany(buf []byte, src []UUID) []byte {
+	n := 36 * len(src)
+	_ = buf[:n]
+	for i := range src {
+		src[i].Encode(buf[i*36 : i*36+36])
+	}
+	return buf[:n]
+}
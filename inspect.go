@@ -0,0 +1,125 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Info describes the fields of a UUID, as decoded by Inspect.
+// Fields that don't apply to the UUID's version are left zero.
+type Info struct {
+	UUID    UUID
+	Version byte
+	Variant byte
+
+	ValidVariant bool // variant bits are VariantRFC9562
+	ValidVersion bool // ValidVariant and the version is defined by RFC-9562
+
+	Time    time.Time // V1, V6 and V7
+	HasTime bool
+
+	ClockSeq uint16  // V1 and V6, 14 bits
+	Node     [6]byte // V1 and V6
+	HasNode  bool
+
+	RandA uint16 // V7, 12 bits; the counter for UUIDs from NewV7
+
+	CustomA uint64 // V8, 48 bits
+	CustomB uint16 // V8, 12 bits
+	CustomC uint64 // V8, 62 bits
+}
+
+// Inspect decodes every field of u. It builds on Version, Variant, Time,
+// ClockSequence and NodeID.
+func Inspect(u UUID) Info {
+	info := Info{
+		UUID:    u,
+		Version: u.Version(),
+		Variant: u.Variant(),
+	}
+	info.ValidVariant = info.Variant == VariantRFC9562
+	info.ValidVersion = info.ValidVariant && info.Version >= V1 && info.Version <= V8
+
+	info.Time, info.HasTime = u.Time()
+	info.ClockSeq, _ = u.ClockSequence()
+	info.Node, info.HasNode = u.NodeID()
+
+	if !info.ValidVariant {
+		return info
+	}
+	switch info.Version {
+	case V7:
+		info.RandA = binary.BigEndian.Uint16(u[6:8]) & 0x0FFF
+	case V8:
+		info.CustomA = binary.BigEndian.Uint64(u[0:8]) >> 16
+		info.CustomB = binary.BigEndian.Uint16(u[6:8]) & 0x0FFF
+		info.CustomC = binary.BigEndian.Uint64(u[8:16]) & 0x3FFFFFFFFFFFFFFF
+	}
+	return info
+}
+
+var variantNames = [...]string{
+	VariantNCS:       "NCS (reserved)",
+	VariantRFC9562:   "RFC 9562",
+	VariantMicrosoft: "Microsoft (reserved)",
+	VariantFuture:    "future (reserved)",
+}
+
+var versionNames = [...]string{
+	V1: "Gregorian time-based",
+	2:  "DCE Security",
+	V3: "name-based, MD5",
+	V4: "random",
+	V5: "name-based, SHA-1",
+	V6: "reordered Gregorian time-based",
+	V7: "Unix Epoch time-based",
+	V8: "custom",
+}
+
+// String returns a multi-line, human-readable description of the UUID.
+func (info Info) String() string {
+	var sb strings.Builder
+	field := func(name, format string, args ...any) {
+		fmt.Fprintf(&sb, "%-10s", name+":")
+		fmt.Fprintf(&sb, format, args...)
+		sb.WriteByte('\n')
+	}
+
+	field("UUID", "%s", info.UUID)
+	switch info.UUID {
+	case NilUUID:
+		field("Special", "nil UUID")
+	case Max:
+		field("Special", "max UUID")
+	}
+
+	field("Variant", "%s", variantNames[info.Variant])
+	if !info.ValidVariant {
+		return sb.String()
+	}
+	if info.ValidVersion {
+		field("Version", "%d (%s)", info.Version, versionNames[info.Version])
+	} else {
+		field("Version", "%d (reserved)", info.Version)
+	}
+
+	if info.HasTime {
+		field("Time", "%s", info.Time.UTC().Format(time.RFC3339Nano))
+	}
+	if info.HasNode {
+		field("ClockSeq", "%#04x", info.ClockSeq)
+		field("Node", "%02x:%02x:%02x:%02x:%02x:%02x",
+			info.Node[0], info.Node[1], info.Node[2], info.Node[3], info.Node[4], info.Node[5])
+	}
+	switch info.Version {
+	case V7:
+		field("RandA", "%#03x", info.RandA)
+	case V8:
+		field("CustomA", "%#012x", info.CustomA)
+		field("CustomB", "%#03x", info.CustomB)
+		field("CustomC", "%#016x", info.CustomC)
+	}
+	return sb.String()
+}
@@ -0,0 +1,104 @@
+package uuid
+
+// NilAsNull is a UUID whose JSON form encodes the nil UUID as null
+// instead of "00000000-0000-0000-0000-000000000000". Both null and the
+// nil UUID string decode to the nil UUID.
+type NilAsNull UUID
+
+// NilAsEmpty is a UUID whose JSON form encodes the nil UUID as the empty
+// string "". Both "" and null decode to the nil UUID.
+type NilAsEmpty UUID
+
+var emptyJSON = []byte(`""`)
+
+// IsZero reports whether u is the nil UUID.
+func (u NilAsNull) IsZero() bool {
+	return UUID(u) == NilUUID
+}
+
+// String returns the canonical string form of the UUID.
+func (u NilAsNull) String() string {
+	return UUID(u).String()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u NilAsNull) MarshalJSON() ([]byte, error) {
+	if u.IsZero() {
+		return nullJSON, nil
+	}
+	return UUID(u).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (u *NilAsNull) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*u = NilAsNull{}
+		return nil
+	}
+	return (*UUID)(u).UnmarshalJSON(b)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The nil UUID is encoded as empty text.
+func (u NilAsNull) MarshalText() ([]byte, error) {
+	if u.IsZero() {
+		return []byte{}, nil
+	}
+	return UUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// Empty text decodes to the nil UUID.
+func (u *NilAsNull) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*u = NilAsNull{}
+		return nil
+	}
+	return (*UUID)(u).UnmarshalText(b)
+}
+
+// IsZero reports whether u is the nil UUID.
+func (u NilAsEmpty) IsZero() bool {
+	return UUID(u) == NilUUID
+}
+
+// String returns the canonical string form of the UUID.
+func (u NilAsEmpty) String() string {
+	return UUID(u).String()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u NilAsEmpty) MarshalJSON() ([]byte, error) {
+	if u.IsZero() {
+		return emptyJSON, nil
+	}
+	return UUID(u).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (u *NilAsEmpty) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" || string(b) == `""` {
+		*u = NilAsEmpty{}
+		return nil
+	}
+	return (*UUID)(u).UnmarshalJSON(b)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The nil UUID is encoded as empty text.
+func (u NilAsEmpty) MarshalText() ([]byte, error) {
+	if u.IsZero() {
+		return []byte{}, nil
+	}
+	return UUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// Empty text decodes to the nil UUID.
+func (u *NilAsEmpty) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*u = NilAsEmpty{}
+		return nil
+	}
+	return (*UUID)(u).UnmarshalText(b)
+}
@@ -0,0 +1,52 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+package uuid
+
+import (
+	"bytes"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+)
+
+var (
+	_ json.MarshalerTo     = UUID{}
+	_ json.UnmarshalerFrom = (*UUID)(nil)
+)
+
+// MarshalJSONTo implements the json.MarshalerTo interface.
+// The canonical form is written straight into the encoder without
+// allocating an intermediate string.
+func (u UUID) MarshalJSONTo(enc *jsontext.Encoder) error {
+	var buf [38]byte
+	buf[0], buf[37] = '"', '"'
+	encodeCanonical(buf[1:37], u)
+	return enc.WriteValue(buf[:])
+}
+
+// UnmarshalJSONFrom implements the json.UnmarshalerFrom interface.
+// A JSON null is a no-op; any other value must be a JSON string holding
+// one of the formats supported by UnmarshalText. On error u is left
+// unchanged.
+func (u *UUID) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	val, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	switch val.Kind() {
+	case 'n':
+		return nil
+	case '"':
+	default:
+		return fmt.Errorf("%w: cannot unmarshal non-string JSON value %q", ErrInvalidFormat, []byte(val))
+	}
+
+	b := val[1 : len(val)-1]
+	if bytes.IndexByte(b, '\\') >= 0 {
+		// Escaped strings are rare for UUIDs; only unquote when needed.
+		if b, err = jsontext.AppendUnquote(nil, val); err != nil {
+			return err
+		}
+	}
+	return parseInto(b, u)
+}
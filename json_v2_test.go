@@ -0,0 +1,70 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+package uuid
+
+import (
+	jsonv1 "encoding/json"
+	"encoding/json/v2"
+	"errors"
+	"testing"
+)
+
+func TestUUID_JSONv2(t *testing.T) {
+	u := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	data, err := json.Marshal(u)
+	if err != nil || string(data) != `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"` {
+		t.Errorf("json.Marshal() = %s, %v", data, err)
+	}
+
+	for _, in := range []string{
+		`"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`,
+		`"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"`,
+		`"\u0036ba7b810-9dad-11d1-80b4-00c04fd430c8"`,
+		`"urn\u003auuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8"`,
+	} {
+		var v2, v1 UUID
+		if err := json.Unmarshal([]byte(in), &v2); err != nil || v2 != u {
+			t.Errorf("v2 json.Unmarshal(%s) = %s, %v, want %s", in, v2, err, u)
+		}
+		if err := jsonv1.Unmarshal([]byte(in), &v1); err != nil || v1 != v2 {
+			t.Errorf("v1 json.Unmarshal(%s) = %s, %v, want %s as in v2", in, v1, err, v2)
+		}
+	}
+
+	got := u
+	if err := json.Unmarshal([]byte("null"), &got); err != nil || got != u {
+		t.Errorf("json.Unmarshal(null) = %s, %v, want receiver unchanged", got, err)
+	}
+
+	for _, in := range []string{`123`, `true`, `"6ba7b810"`} {
+		var got UUID
+		err := json.Unmarshal([]byte(in), &got)
+		if !errors.Is(err, ErrInvalidFormat) && !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("json.Unmarshal(%s) error = %v, want invalid format or length", in, err)
+		}
+	}
+
+	const bad = `"6ba7b810-9dad-11d1-80b4-00c04fd43zzz"`
+	got = Max
+	if err := json.Unmarshal([]byte(bad), &got); err == nil || got != Max {
+		t.Errorf("json.Unmarshal(%s) = %s, %v, want error and receiver unchanged", bad, got, err)
+	}
+
+	type row struct {
+		ID   UUID
+		List []UUID
+	}
+	in := row{ID: u, List: []UUID{NilUUID, u, Max}}
+	data, err = json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v1, _ := jsonv1.Marshal(in); string(v1) != string(data) {
+		t.Errorf("v1 and v2 encodings differ:\n v1: %s\n v2: %s", v1, data)
+	}
+	var out row
+	if err := json.Unmarshal(data, &out); err != nil || out.ID != u || len(out.List) != 3 || out.List[2] != Max {
+		t.Errorf("round trip = %+v, %v, want %+v", out, err, in)
+	}
+}
@@ -0,0 +1,89 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+var _ driver.Valuer = MySQLSwapped{}
+var _ sql.Scanner = (*MySQLSwapped)(nil)
+
+// FromMySQLSwapped returns the UUID stored in the layout produced by
+// MySQL's UUID_TO_BIN(uuid, 1), in which the time-low and time-high fields
+// are swapped. It is the Go equivalent of BIN_TO_UUID(b, 1).
+// It will return an error if the slice isn't 16 bytes long.
+func FromMySQLSwapped(b []byte) (UUID, error) {
+	var u UUID
+	if len(b) != 16 {
+		return u, fmt.Errorf("%w: swapped UUID must be exactly 16 bytes long, got %d bytes", ErrInvalidLength, len(b))
+	}
+	copy(u[0:4], b[4:8])
+	copy(u[4:6], b[2:4])
+	copy(u[6:8], b[0:2])
+	copy(u[8:], b[8:])
+	return u, nil
+}
+
+// ToMySQLSwapped returns a newly allocated byte slice containing the UUID
+// in the layout produced by MySQL's UUID_TO_BIN(uuid, 1):
+// time-high, time-mid, time-low, then the remaining 8 bytes unchanged.
+func (u UUID) ToMySQLSwapped() []byte {
+	b := make([]byte, 16)
+	copy(b[0:2], u[6:8])
+	copy(b[2:4], u[4:6])
+	copy(b[4:8], u[0:4])
+	copy(b[8:], u[8:])
+	return b
+}
+
+// MySQLSwapped is a UUID stored in a BINARY(16) column using MySQL's
+// swap_flag layout, interoperating with UUID_TO_BIN(x, 1) and
+// BIN_TO_UUID(x, 1).
+type MySQLSwapped UUID
+
+// String returns the canonical string form of the UUID.
+func (u MySQLSwapped) String() string {
+	return UUID(u).String()
+}
+
+// Value implements the driver.Valuer interface.
+// The UUID is emitted as 16 bytes in swapped layout.
+func (u MySQLSwapped) Value() (driver.Value, error) {
+	return UUID(u).ToMySQLSwapped(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// A 16-byte slice or array is interpreted in swapped layout,
+// while a string or a longer byte slice is parsed as UUID text.
+func (u *MySQLSwapped) Scan(src any) error {
+	if b, ok := rawBinary(src); ok {
+		uu, err := FromMySQLSwapped(b)
+		if err != nil {
+			return err
+		}
+		*u = MySQLSwapped(uu)
+		return nil
+	}
+	return (*UUID)(u).Scan(src)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u MySQLSwapped) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (u *MySQLSwapped) UnmarshalText(b []byte) error {
+	return (*UUID)(u).UnmarshalText(b)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u MySQLSwapped) MarshalJSON() ([]byte, error) {
+	return UUID(u).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (u *MySQLSwapped) UnmarshalJSON(b []byte) error {
+	return (*UUID)(u).UnmarshalJSON(b)
+}
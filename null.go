@@ -0,0 +1,101 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+var _ driver.Valuer = Null[UUID]{}
+var _ sql.Scanner = (*Null[UUID])(nil)
+
+// Null is a nullable wrapper for UUID and for any named type whose
+// underlying type is a UUID, such as
+//
+//	type UserID uuid.UUID
+//
+//	var owner uuid.Null[UserID]
+//
+// It behaves like NullUUID for SQL and JSON, so typed entity IDs don't need
+// their own copy of it.
+type Null[T ~[16]byte] struct {
+	V     T
+	Valid bool
+}
+
+// NullOf returns a valid Null holding v.
+func NullOf[T ~[16]byte](v T) Null[T] {
+	return Null[T]{V: v, Valid: true}
+}
+
+// IsZero reports whether n is NULL, so that `omitzero` drops invalid values.
+func (n Null[T]) IsZero() bool {
+	return !n.Valid
+}
+
+// Value implements the driver.Valuer interface.
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return UUID(n.V).Value()
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *Null[T]) Scan(src any) error {
+	if src == nil {
+		n.V, n.Valid = T{}, false
+		return nil
+	}
+	var u UUID
+	if err := u.Scan(src); err != nil {
+		return err
+	}
+	n.V, n.Valid = T(u), true
+	return nil
+}
+
+// MarshalJSON marshals the Null as null or the nested UUID.
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return nullJSON, nil
+	}
+	return UUID(n.V).MarshalJSON()
+}
+
+// UnmarshalJSON unmarshals a Null from null or a JSON string.
+func (n *Null[T]) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		n.V, n.Valid = T{}, false
+		return nil
+	}
+	var u UUID
+	if err := u.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	n.V, n.Valid = T(u), true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// A NULL value is encoded as empty text.
+func (n Null[T]) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return UUID(n.V).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// Empty text decodes to NULL.
+func (n *Null[T]) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		n.V, n.Valid = T{}, false
+		return nil
+	}
+	var u UUID
+	if err := u.UnmarshalText(b); err != nil {
+		return err
+	}
+	n.V, n.Valid = T(u), true
+	return nil
+}
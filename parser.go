@@ -0,0 +1,238 @@
+package uuid
+
+import "unsafe"
+
+// Form is a set of UUID text forms accepted by a Parser.
+type Form uint8
+
+// UUID text forms.
+const (
+	FormCanonical Form = 1 << iota // 6ba7b810-9dad-11d1-80b4-00c04fd430c8
+	FormHash                       // 6ba7b8109dad11d180b400c04fd430c8
+	FormBraced                     // {...} around a canonical or hash form
+	FormURN                        // urn:uuid:... before a canonical or hash form
+
+	FormAll = FormCanonical | FormHash | FormBraced | FormURN
+)
+
+// Case is the letter case a Parser accepts for hex digits.
+type Case uint8
+
+// Hex digit cases.
+const (
+	AnyCase   Case = iota // mixed case, as accepted by Parse
+	LowerCase             // a-f only
+	UpperCase             // A-F only
+)
+
+// Parser parses the text form of UUIDs under configurable rules.
+//
+// The zero Parser accepts every form with hex digits in any case and is
+// what Parse, ParseBytes and UnmarshalText use.
+type Parser struct {
+	// Forms is the set of accepted forms. Zero means FormAll.
+	// FormBraced and FormURN wrap whichever of FormCanonical and FormHash
+	// are also accepted.
+	Forms Form
+
+	// Case restricts the case of hex digits.
+	Case Case
+
+	// FoldURN matches the "urn:uuid:" prefix case-insensitively. Without
+	// it the prefix must be lower case, as Parse has always required.
+	FoldURN bool
+
+	// TrimSpace removes leading and trailing ASCII white space before
+	// parsing.
+	TrimSpace bool
+}
+
+// Parser presets.
+var (
+	// StrictParser only accepts the lower-case canonical form, as produced
+	// by UUID.String.
+	StrictParser = Parser{Forms: FormCanonical, Case: LowerCase}
+
+	// LenientParser accepts every form in any case, including upper-case
+	// URNs, and ignores surrounding white space.
+	LenientParser = Parser{Forms: FormAll, Case: AnyCase, FoldURN: true, TrimSpace: true}
+)
+
+// Parse parses the UUID stored in the string s according to p.
+// On failure the nil UUID and a *ParseError are returned.
+func (p Parser) Parse(s string) (UUID, error) {
+	u := UUID{}
+	if err := p.parse(stringBytes(s), &u); err != nil {
+		return NilUUID, err
+	}
+	return u, nil
+}
+
+// ParseBytes is like Parse but takes a byte slice.
+func (p Parser) ParseBytes(b []byte) (UUID, error) {
+	u := UUID{}
+	if err := p.parse(b, &u); err != nil {
+		return NilUUID, err
+	}
+	return u, nil
+}
+
+// stringBytes returns the bytes of s without copying. The parser only
+// reads its input, and error values copy it, so s is never mutated or
+// retained.
+func stringBytes(s string) []byte {
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// fromHex is fromHexChar restricted to the parser's case rule.
+func (p Parser) fromHex(c byte) byte {
+	switch p.Case {
+	case LowerCase:
+		if 'A' <= c && c <= 'F' {
+			return 255
+		}
+	case UpperCase:
+		if 'a' <= c && c <= 'f' {
+			return 255
+		}
+	}
+	return fromHexChar(c)
+}
+
+func (p Parser) expectedHex() string {
+	switch p.Case {
+	case LowerCase:
+		return "lower-case hex digit"
+	case UpperCase:
+		return "upper-case hex digit"
+	}
+	return "hex digit"
+}
+
+func (p Parser) parse(b []byte, u *UUID) error {
+	// Fast-path: ensure we don't accidentally mutate the caller's slice.
+	// We will only reslice, never modify the underlying bytes.
+	in, off := b, 0
+	if p.TrimSpace {
+		for len(b) > 0 && isSpace(b[0]) {
+			b, off = b[1:], off+1
+		}
+		for len(b) > 0 && isSpace(b[len(b)-1]) {
+			b = b[:len(b)-1]
+		}
+	}
+
+	forms := p.Forms
+	if forms == 0 {
+		forms = FormAll
+	}
+
+	switch len(b) {
+	case 32: // hash
+		if forms&FormHash == 0 {
+			return newParseError(in, -1, "canonical form", ErrInvalidFormat)
+		}
+	case 36: // canonical
+		if forms&FormCanonical == 0 {
+			return newParseError(in, -1, "hash-like form", ErrInvalidFormat)
+		}
+	case 34, 38:
+		if forms&FormBraced == 0 || forms&(FormCanonical|FormHash) == 0 {
+			return newParseError(in, off, "unbraced form", ErrInvalidFormat)
+		}
+		if b[0] != '{' {
+			return newParseError(in, off, "'{'", ErrInvalidFormat)
+		}
+		if b[len(b)-1] != '}' {
+			return newParseError(in, off+len(b)-1, "'}'", ErrInvalidFormat)
+		}
+		b, off = b[1:len(b)-1], off+1
+	case 41, 45:
+		if forms&FormURN == 0 || forms&(FormCanonical|FormHash) == 0 {
+			return newParseError(in, off, "form without URN prefix", ErrInvalidFormat)
+		}
+		if !p.hasURNPrefix(b) {
+			return newParseError(in, off, `"urn:uuid:" prefix`, ErrInvalidFormat)
+		}
+		b, off = b[9:], off+9
+	default:
+		return newParseError(in, -1, "32, 34, 36, 38, 41 or 45 characters", ErrInvalidLength)
+	}
+
+	// canonical (36 chars with dashes at fixed positions)
+	if len(b) == 36 {
+		if forms&FormCanonical == 0 {
+			return newParseError(in, off, "hash-like form", ErrInvalidFormat)
+		}
+		for _, x := range [4]int{8, 13, 18, 23} {
+			if b[x] != '-' {
+				return newParseError(in, off+x, "'-'", ErrInvalidFormat)
+			}
+		}
+		if decodeCanonical(b, u, p.Case) {
+			return nil
+		}
+		// Slow path: locate the offending character.
+		for i, x := range [16]byte{
+			0, 2, 4, 6,
+			9, 11,
+			14, 16,
+			19, 21,
+			24, 26, 28, 30, 32, 34,
+		} {
+			v1 := p.fromHex(b[x])
+			v2 := p.fromHex(b[x+1])
+			if v1|v2 == 255 {
+				if v1 != 255 {
+					x++
+				}
+				return newParseError(in, off+int(x), p.expectedHex(), ErrInvalidChar)
+			}
+			u[i] = (v1 << 4) | v2
+		}
+		return nil
+	}
+
+	// hash-like (32 hex chars, no dashes)
+	if forms&FormHash == 0 {
+		return newParseError(in, off, "canonical form", ErrInvalidFormat)
+	}
+	if decodeHash(b, u, p.Case) {
+		return nil
+	}
+	// Slow path: locate the offending character.
+	for i := 0; i < 32; i += 2 {
+		v1 := p.fromHex(b[i])
+		v2 := p.fromHex(b[i+1])
+		if v1|v2 == 255 {
+			x := i
+			if v1 != 255 {
+				x++
+			}
+			return newParseError(in, off+x, p.expectedHex(), ErrInvalidChar)
+		}
+		u[i/2] = (v1 << 4) | v2
+	}
+	return nil
+}
+
+func (p Parser) hasURNPrefix(b []byte) bool {
+	const prefix = "urn:uuid:"
+	if !p.FoldURN {
+		return string(b[:9]) == prefix
+	}
+	for i := range len(prefix) {
+		c := b[i]
+		if 'A' <= c && c <= 'Z' {
+			c |= 0x20 // fold letters only; 0x1a|0x20 would otherwise match ':'
+		}
+		if c != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,8 @@
+module github.com/yonomesh/uuid/pgxuuid
+
+go 1.25.6
+
+require (
+	github.com/jackc/pgx/v5 v5.11.0
+	github.com/yonomesh/uuid v0.0.0-20261018151147-bc3164f71402
+)
@@ -0,0 +1,186 @@
+// Package pgxuuid registers uuid.UUID and uuid.NullUUID with a pgx type map,
+// so that PostgreSQL uuid and uuid[] values are transferred in the 16-byte
+// binary wire format instead of being formatted and parsed as text.
+//
+// Register the types once per connection, e.g. from AfterConnect:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		pgxuuid.Register(conn.TypeMap())
+//		return nil
+//	}
+//
+// pgxuuid is a separate module, so depending on github.com/yonomesh/uuid
+// alone does not add pgx to a module graph.
+package pgxuuid
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/yonomesh/uuid"
+)
+
+// UUID is a uuid.UUID that implements the pgtype UUIDScanner and
+// UUIDValuer interfaces.
+type UUID uuid.UUID
+
+// ScanUUID implements the pgtype.UUIDScanner interface.
+func (u *UUID) ScanUUID(v pgtype.UUID) error {
+	if !v.Valid {
+		return errors.New("pgxuuid: cannot scan NULL into *uuid.UUID")
+	}
+	*u = v.Bytes
+	return nil
+}
+
+// UUIDValue implements the pgtype.UUIDValuer interface.
+func (u UUID) UUIDValue() (pgtype.UUID, error) {
+	return pgtype.UUID{Bytes: u, Valid: true}, nil
+}
+
+// NullUUID is a uuid.NullUUID that implements the pgtype UUIDScanner and
+// UUIDValuer interfaces.
+type NullUUID uuid.NullUUID
+
+// ScanUUID implements the pgtype.UUIDScanner interface.
+func (u *NullUUID) ScanUUID(v pgtype.UUID) error {
+	*u = NullUUID{UUID: v.Bytes, Valid: v.Valid}
+	return nil
+}
+
+// UUIDValue implements the pgtype.UUIDValuer interface.
+func (u NullUUID) UUIDValue() (pgtype.UUID, error) {
+	return pgtype.UUID{Bytes: u.UUID, Valid: u.Valid}, nil
+}
+
+// Codec is a pgtype.Codec for the PostgreSQL uuid type that scans into and
+// encodes from uuid.UUID and uuid.NullUUID directly, in binary format.
+// Other Go types are handled as by pgtype.UUIDCodec.
+type Codec struct {
+	pgtype.UUIDCodec
+}
+
+// PlanEncode implements the pgtype.Codec interface.
+func (c Codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	switch value.(type) {
+	case uuid.UUID:
+		if next := c.UUIDCodec.PlanEncode(m, oid, format, UUID{}); next != nil {
+			return &encodePlan{next: next}
+		}
+		return nil
+	case uuid.NullUUID:
+		if next := c.UUIDCodec.PlanEncode(m, oid, format, NullUUID{}); next != nil {
+			return &encodePlan{next: next}
+		}
+		return nil
+	}
+	return c.UUIDCodec.PlanEncode(m, oid, format, value)
+}
+
+// PlanScan implements the pgtype.Codec interface.
+func (c Codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	switch target.(type) {
+	case *uuid.UUID:
+		if next := c.UUIDCodec.PlanScan(m, oid, format, (*UUID)(nil)); next != nil {
+			return &scanPlan{next: next}
+		}
+		return nil
+	case *uuid.NullUUID:
+		if next := c.UUIDCodec.PlanScan(m, oid, format, (*NullUUID)(nil)); next != nil {
+			return &scanPlan{next: next}
+		}
+		return nil
+	}
+	return c.UUIDCodec.PlanScan(m, oid, format, target)
+}
+
+// DecodeValue implements the pgtype.Codec interface.
+// A non-NULL value is returned as a uuid.UUID.
+func (c Codec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+	var u uuid.UUID
+	plan := c.PlanScan(m, oid, format, &u)
+	if plan == nil {
+		return nil, fmt.Errorf("pgxuuid: cannot decode format %d", format)
+	}
+	if err := plan.Scan(src, &u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+type encodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (p *encodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	switch v := value.(type) {
+	case uuid.UUID:
+		return p.next.Encode(UUID(v), buf)
+	case uuid.NullUUID:
+		return p.next.Encode(NullUUID(v), buf)
+	}
+	return nil, fmt.Errorf("pgxuuid: unexpected value %T", value)
+}
+
+type scanPlan struct {
+	next pgtype.ScanPlan
+}
+
+func (p *scanPlan) Scan(src []byte, dst any) error {
+	switch d := dst.(type) {
+	case *uuid.UUID:
+		return p.next.Scan(src, (*UUID)(d))
+	case *uuid.NullUUID:
+		return p.next.Scan(src, (*NullUUID)(d))
+	}
+	return fmt.Errorf("pgxuuid: unexpected target %T", dst)
+}
+
+// arrayCodec routes uuid.UUIDs and uuid.NullUUIDs through their plain
+// slice types; both implement sql.Scanner, which pgx would otherwise prefer
+// over the binary array plan.
+type arrayCodec struct {
+	*pgtype.ArrayCodec
+}
+
+// PlanScan implements the pgtype.Codec interface.
+func (c arrayCodec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	switch target.(type) {
+	case *uuid.UUIDs:
+		return &arrayScanPlan{next: m.PlanScan(oid, format, (*[]uuid.UUID)(nil))}
+	case *uuid.NullUUIDs:
+		return &arrayScanPlan{next: m.PlanScan(oid, format, (*[]uuid.NullUUID)(nil))}
+	}
+	return c.ArrayCodec.PlanScan(m, oid, format, target)
+}
+
+type arrayScanPlan struct {
+	next pgtype.ScanPlan
+}
+
+func (p *arrayScanPlan) Scan(src []byte, dst any) error {
+	switch d := dst.(type) {
+	case *uuid.UUIDs:
+		return p.next.Scan(src, (*[]uuid.UUID)(d))
+	case *uuid.NullUUIDs:
+		return p.next.Scan(src, (*[]uuid.NullUUID)(d))
+	}
+	return fmt.Errorf("pgxuuid: unexpected target %T", dst)
+}
+
+// Register registers the uuid and uuid[] types with tm so that uuid.UUID,
+// uuid.NullUUID, uuid.UUIDs, uuid.NullUUIDs and slices thereof use the
+// binary wire format.
+func Register(tm *pgtype.Map) {
+	t := &pgtype.Type{Name: "uuid", OID: pgtype.UUIDOID, Codec: Codec{}}
+	tm.RegisterType(t)
+	tm.RegisterType(&pgtype.Type{
+		Name:  "_uuid",
+		OID:   pgtype.UUIDArrayOID,
+		Codec: arrayCodec{&pgtype.ArrayCodec{ElementType: t}},
+	})
+}
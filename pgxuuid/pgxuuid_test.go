@@ -0,0 +1,44 @@
+package pgxuuid
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/yonomesh/uuid"
+)
+
+func TestRegister_Binary(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+
+	u := uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	b, err := m.Encode(pgtype.UUIDOID, pgtype.BinaryFormatCode, u, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 16 || uuid.UUID(b) != u {
+		t.Fatalf("Encode(uuid) = %x, want %x", b, u[:])
+	}
+
+	var got uuid.UUID
+	if err := m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, b, &got); err != nil || got != u {
+		t.Errorf("Scan(uuid) = %s, %v, want %s", got, err, u)
+	}
+
+	var null uuid.NullUUID
+	if err := m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, nil, &null); err != nil || null.Valid {
+		t.Errorf("Scan(NULL) = %v, %v, want invalid", null, err)
+	}
+
+	ids := uuid.UUIDs{u, uuid.Max}
+	b, err = m.Encode(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, ids, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotIDs uuid.UUIDs
+	if err := m.Scan(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, b, &gotIDs); err != nil || !slices.Equal(gotIDs, ids) {
+		t.Errorf("Scan(uuid[]) = %v, %v, want %v", gotIDs, err, ids)
+	}
+}
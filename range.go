@@ -0,0 +1,136 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// maxV7Millis is the largest timestamp a V7 UUID can hold, in the year 10889.
+const maxV7Millis = 1<<48 - 1
+
+// v7Millis returns the V7 timestamp of t, clamped to [0, maxV7Millis]
+// so that out-of-range times never wrap around.
+func v7Millis(t time.Time) uint64 {
+	ms := t.UnixMilli()
+	switch {
+	case ms < 0:
+		return 0
+	case ms > maxV7Millis:
+		return maxV7Millis
+	}
+	return uint64(ms)
+}
+
+// MinV7 returns the smallest V7 UUID whose timestamp is the millisecond
+// of t: every random bit is zero. Times before 1970 give the smallest and
+// times past the 48-bit millisecond range give the largest V7 timestamp.
+func MinV7(t time.Time) UUID {
+	var u UUID
+	binary.BigEndian.PutUint64(u[:8], v7Millis(t)<<16)
+	u.SetVersion(V7)
+	u.SetVariant(VariantRFC9562)
+	return u
+}
+
+// MaxV7 returns the largest V7 UUID whose timestamp is the millisecond
+// of t: every random bit is one. Out-of-range times are clamped as in MinV7.
+func MaxV7(t time.Time) UUID {
+	u := Max
+	binary.BigEndian.PutUint64(u[:8], v7Millis(t)<<16|0xFFFF)
+	u.SetVersion(V7)
+	u.SetVariant(VariantRFC9562)
+	return u
+}
+
+// V7Range returns the inclusive bounds covering every V7 UUID generated
+// from the millisecond of from up to and including the millisecond of to,
+// for index range scans such as
+//
+//	lo, hi := uuid.V7Range(t1, t2)
+//	db.Query("SELECT ... WHERE id BETWEEN $1 AND $2", lo, hi)
+func V7Range(from, to time.Time) (lo, hi UUID) {
+	return MinV7(from), MaxV7(to)
+}
+
+// Range is an inclusive range [Lo, Hi] of UUIDs under Compare ordering,
+// i.e. of 128-bit unsigned integers.
+type Range struct {
+	Lo, Hi UUID
+}
+
+// Empty reports whether r holds no UUID, i.e. Lo > Hi.
+func (r Range) Empty() bool {
+	return r.Lo.Compare(r.Hi) > 0
+}
+
+// Contains reports whether u lies within r.
+func (r Range) Contains(u UUID) bool {
+	return r.Lo.Compare(u) <= 0 && u.Compare(r.Hi) <= 0
+}
+
+// Overlaps reports whether r and s have at least one UUID in common.
+func (r Range) Overlaps(s Range) bool {
+	_, ok := r.Intersect(s)
+	return ok
+}
+
+// Intersect returns the range of UUIDs in both r and s. It reports false
+// if they don't overlap.
+func (r Range) Intersect(s Range) (Range, bool) {
+	out := r
+	if s.Lo.Compare(out.Lo) > 0 {
+		out.Lo = s.Lo
+	}
+	if s.Hi.Compare(out.Hi) < 0 {
+		out.Hi = s.Hi
+	}
+	if out.Empty() {
+		return Range{}, false
+	}
+	return out, true
+}
+
+// SplitRange splits the inclusive range [lo, hi] into n contiguous,
+// non-overlapping ranges of near-equal size, in ascending order. Sizes
+// differ by at most one. If the range holds fewer than n UUIDs, one range
+// per UUID is returned. It returns nil if n < 1 or lo > hi.
+//
+// Bounds from V7Range split the keyspace of a time window, e.g. for
+// parallel backfills:
+//
+//	lo, hi := uuid.V7Range(t1, t2)
+//	for _, r := range uuid.SplitRange(lo, hi, workers) { ... }
+func SplitRange(lo, hi UUID, n int) []Range {
+	if n < 1 || lo.Compare(hi) > 0 {
+		return nil
+	}
+	if n == 1 {
+		return []Range{{Lo: lo, Hi: hi}}
+	}
+
+	// The range holds span+1 UUIDs, which overflows for the full keyspace,
+	// so derive (span+1)/n and (span+1)%n from span/n and span%n.
+	span := hi.sub128(lo)
+	if spanHi, spanLo := span.Uint128(); spanHi == 0 && spanLo < uint64(n) {
+		n = int(spanLo) + 1
+	}
+	q, r := span.divmod(uint64(n))
+	if r+1 == uint64(n) {
+		q, r = q.Next(), 0
+	} else {
+		r++
+	}
+
+	out := make([]Range, n)
+	step := q.Prev()
+	cur := lo
+	for i := range out {
+		end := cur.add128(step)
+		if uint64(i) < r {
+			end = end.Next()
+		}
+		out[i] = Range{Lo: cur, Hi: end}
+		cur = end.Next()
+	}
+	return out
+}
@@ -0,0 +1,390 @@
+package uuid
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"iter"
+)
+
+// Control bytes of table slots. A full slot stores 0x80 | 7 bits of the
+// hash, so most mismatching probes are rejected without touching the key.
+const (
+	ctrlEmpty   = 0x00
+	ctrlDeleted = 0x01
+)
+
+// table is an open-addressing hash table with linear probing, keyed by
+// UUID and hashed with Hash64. It stores 17 bytes per slot plus the value
+// and keeps the load factor, tombstones included, at most 3/4.
+type table[V any] struct {
+	ctrl []byte
+	keys []UUID
+	vals []V
+	n    int // live entries
+	used int // live entries and tombstones
+}
+
+func ctrlOf(h uint64) byte {
+	return byte(h>>57) | 0x80
+}
+
+// lookup returns the slot holding u.
+func (t *table[V]) lookup(u UUID) (int, bool) {
+	if t.n == 0 {
+		return 0, false
+	}
+	h := u.Hash64()
+	c := ctrlOf(h)
+	mask := len(t.ctrl) - 1
+	for i := int(h) & mask; ; i = (i + 1) & mask {
+		switch t.ctrl[i] {
+		case ctrlEmpty:
+			return 0, false
+		case c:
+			if t.keys[i] == u {
+				return i, true
+			}
+		}
+	}
+}
+
+// insert returns the slot for u, claiming a new one if u is not present.
+func (t *table[V]) insert(u UUID) (int, bool) {
+	if t.full(1) {
+		t.grow()
+	}
+	h := u.Hash64()
+	c := ctrlOf(h)
+	mask := len(t.ctrl) - 1
+	slot := -1
+	for i := int(h) & mask; ; i = (i + 1) & mask {
+		switch t.ctrl[i] {
+		case ctrlEmpty:
+			if slot < 0 {
+				slot = i
+				t.used++
+			}
+			t.ctrl[slot] = c
+			t.keys[slot] = u
+			t.n++
+			return slot, false
+		case ctrlDeleted:
+			if slot < 0 {
+				slot = i
+			}
+		case c:
+			if t.keys[i] == u {
+				return i, true
+			}
+		}
+	}
+}
+
+func (t *table[V]) remove(u UUID) bool {
+	i, ok := t.lookup(u)
+	if !ok {
+		return false
+	}
+	var zero V
+	t.ctrl[i] = ctrlDeleted
+	t.vals[i] = zero
+	t.n--
+	return true
+}
+
+// full reports whether adding n more entries would exceed the load factor.
+func (t *table[V]) full(n int) bool {
+	return (t.used+n)*4 > len(t.ctrl)*3
+}
+
+// grow makes room for one more entry. Dropping tombstones in place is
+// enough if they take up at least 1/8 of the slots; otherwise the table
+// doubles. Either way the next rehash is len/8 inserts away, so removing
+// and adding entries at a full table stays amortized O(1).
+func (t *table[V]) grow() {
+	size := len(t.ctrl)
+	switch {
+	case size == 0:
+		size = 8
+	case t.used-t.n < size/8:
+		size *= 2
+	}
+	t.resize(size)
+}
+
+// rehash resizes the table to the smallest size that holds n entries.
+func (t *table[V]) rehash(n int) {
+	size := 8
+	for size*3 < n*4 {
+		size *= 2
+	}
+	t.resize(size)
+}
+
+// resize moves the entries into a table of the given size, dropping
+// tombstones.
+func (t *table[V]) resize(size int) {
+	old := *t
+	*t = table[V]{
+		ctrl: make([]byte, size),
+		keys: make([]UUID, size),
+		vals: make([]V, size),
+	}
+	for i, c := range old.ctrl {
+		if c&0x80 != 0 {
+			j, _ := t.insert(old.keys[i])
+			t.vals[j] = old.vals[i]
+		}
+	}
+}
+
+// sortedKeys returns the keys in increasing Compare order.
+func (t *table[V]) sortedKeys() []UUID {
+	keys := make([]UUID, 0, t.n)
+	for i, c := range t.ctrl {
+		if c&0x80 != 0 {
+			keys = append(keys, t.keys[i])
+		}
+	}
+	RadixSort(keys)
+	return keys
+}
+
+// Set is a compact set of UUIDs. The zero Set is empty and ready to use.
+// A Set is not safe for concurrent mutation.
+type Set struct {
+	t table[struct{}]
+}
+
+// NewSet returns a set holding ids.
+func NewSet(ids ...UUID) *Set {
+	s := &Set{}
+	s.Grow(len(ids))
+	for _, u := range ids {
+		s.Add(u)
+	}
+	return s
+}
+
+// Grow makes room for n more UUIDs without rehashing.
+func (s *Set) Grow(n int) {
+	if s.t.full(n) {
+		s.t.rehash(s.t.n + n)
+	}
+}
+
+// Len returns the number of UUIDs in s.
+func (s *Set) Len() int { return s.t.n }
+
+// Has reports whether u is in s.
+func (s *Set) Has(u UUID) bool {
+	_, ok := s.t.lookup(u)
+	return ok
+}
+
+// Add adds u to s and reports whether it was not present before.
+func (s *Set) Add(u UUID) bool {
+	_, existed := s.t.insert(u)
+	return !existed
+}
+
+// Remove removes u from s and reports whether it was present.
+func (s *Set) Remove(u UUID) bool {
+	return s.t.remove(u)
+}
+
+// All returns an iterator over the UUIDs of s in increasing Compare order.
+// It iterates over a snapshot taken when iteration starts.
+func (s *Set) All() iter.Seq[UUID] {
+	return func(yield func(UUID) bool) {
+		for _, u := range s.t.sortedKeys() {
+			if !yield(u) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted returns the UUIDs of s in increasing Compare order.
+func (s *Set) Sorted() []UUID {
+	return s.t.sortedKeys()
+}
+
+// Union returns a new set holding the UUIDs in s or o.
+func (s *Set) Union(o *Set) *Set {
+	out := &Set{}
+	out.Grow(s.Len() + o.Len())
+	for _, t := range []*Set{s, o} {
+		for i, c := range t.t.ctrl {
+			if c&0x80 != 0 {
+				out.Add(t.t.keys[i])
+			}
+		}
+	}
+	return out
+}
+
+// Intersect returns a new set holding the UUIDs in both s and o.
+func (s *Set) Intersect(o *Set) *Set {
+	if o.Len() < s.Len() {
+		s, o = o, s
+	}
+	out := &Set{}
+	for i, c := range s.t.ctrl {
+		if c&0x80 != 0 && o.Has(s.t.keys[i]) {
+			out.Add(s.t.keys[i])
+		}
+	}
+	return out
+}
+
+// Difference returns a new set holding the UUIDs in s but not in o.
+func (s *Set) Difference(o *Set) *Set {
+	out := &Set{}
+	for i, c := range s.t.ctrl {
+		if c&0x80 != 0 && !o.Has(s.t.keys[i]) {
+			out.Add(s.t.keys[i])
+		}
+	}
+	return out
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The set is encoded as its UUIDs in increasing Compare order, 16 bytes
+// each.
+func (s *Set) MarshalBinary() ([]byte, error) {
+	keys := s.t.sortedKeys()
+	b := make([]byte, 0, 16*len(keys))
+	for _, u := range keys {
+		b = append(b, u[:]...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// It replaces the contents of s.
+func (s *Set) UnmarshalBinary(data []byte) error {
+	if len(data)%16 != 0 {
+		return fmt.Errorf("%w: set encoding must be a multiple of 16 bytes, got %d bytes", ErrInvalidLength, len(data))
+	}
+	*s = Set{}
+	s.Grow(len(data) / 16)
+	for i := 0; i < len(data); i += 16 {
+		s.Add(UUID(data[i : i+16]))
+	}
+	return nil
+}
+
+// Map is a compact hash map keyed by UUID. The zero Map is empty and ready
+// to use. A Map is not safe for concurrent mutation.
+type Map[V any] struct {
+	t table[V]
+}
+
+// Len returns the number of entries in m.
+func (m *Map[V]) Len() int { return m.t.n }
+
+// Grow makes room for n more entries without rehashing.
+func (m *Map[V]) Grow(n int) {
+	if m.t.full(n) {
+		m.t.rehash(m.t.n + n)
+	}
+}
+
+// Get returns the value stored for u and whether it was present.
+func (m *Map[V]) Get(u UUID) (V, bool) {
+	i, ok := m.t.lookup(u)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return m.t.vals[i], true
+}
+
+// Has reports whether m holds an entry for u.
+func (m *Map[V]) Has(u UUID) bool {
+	_, ok := m.t.lookup(u)
+	return ok
+}
+
+// Put stores v for u, replacing any previous value.
+func (m *Map[V]) Put(u UUID, v V) {
+	i, _ := m.t.insert(u)
+	m.t.vals[i] = v
+}
+
+// Delete removes the entry for u and reports whether it was present.
+func (m *Map[V]) Delete(u UUID) bool {
+	return m.t.remove(u)
+}
+
+// All returns an iterator over the entries of m in increasing Compare
+// order of their keys. It iterates over a snapshot of the keys taken when
+// iteration starts.
+func (m *Map[V]) All() iter.Seq2[UUID, V] {
+	return func(yield func(UUID, V) bool) {
+		for _, u := range m.t.sortedKeys() {
+			v, ok := m.Get(u)
+			if ok && !yield(u, v) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// Entries are encoded in increasing key order as the 16-byte key, the
+// uvarint length of the value and the value's own binary encoding, so V or
+// *V must implement encoding.BinaryMarshaler.
+func (m *Map[V]) MarshalBinary() ([]byte, error) {
+	var b []byte
+	for _, u := range m.t.sortedKeys() {
+		i, _ := m.t.lookup(u)
+		bm, ok := any(m.t.vals[i]).(encoding.BinaryMarshaler)
+		if !ok {
+			if bm, ok = any(&m.t.vals[i]).(encoding.BinaryMarshaler); !ok {
+				return nil, fmt.Errorf("uuid: Map value type %T does not implement encoding.BinaryMarshaler", m.t.vals[i])
+			}
+		}
+		data, err := bm.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, u[:]...)
+		b = binary.AppendUvarint(b, uint64(len(data)))
+		b = append(b, data...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// It replaces the contents of m; *V must implement
+// encoding.BinaryUnmarshaler.
+func (m *Map[V]) UnmarshalBinary(data []byte) error {
+	var out Map[V]
+	for len(data) > 0 {
+		if len(data) < 16 {
+			return fmt.Errorf("%w: truncated Map key", ErrInvalidLength)
+		}
+		u := UUID(data[:16])
+		size, n := binary.Uvarint(data[16:])
+		if n <= 0 || uint64(len(data)-16-n) < size {
+			return fmt.Errorf("%w: truncated Map value", ErrInvalidLength)
+		}
+		data = data[16+n:]
+
+		var v V
+		bu, ok := any(&v).(encoding.BinaryUnmarshaler)
+		if !ok {
+			return fmt.Errorf("uuid: Map value type %T does not implement encoding.BinaryUnmarshaler", &v)
+		}
+		if err := bu.UnmarshalBinary(data[:size]); err != nil {
+			return err
+		}
+		data = data[size:]
+		out.Put(u, v)
+	}
+	*m = out
+	return nil
+}
@@ -0,0 +1,91 @@
+package uuid
+
+import "slices"
+
+// Slice attaches the methods of sort.Interface to []UUID, sorting in
+// increasing Compare order.
+type Slice []UUID
+
+func (s Slice) Len() int           { return len(s) }
+func (s Slice) Less(i, j int) bool { return s[i].Compare(s[j]) < 0 }
+func (s Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// Sort sorts s in increasing Compare order, using RadixSort.
+func (s Slice) Sort() { RadixSort(s) }
+
+// radixCutoff is the bucket size below which RadixSort falls back to a
+// comparison sort.
+const radixCutoff = 256
+
+// RadixSort sorts s in place in increasing Compare order. It is an MSD
+// (American flag) radix sort over the 16 bytes, needing no extra memory
+// besides the recursion stack, and outperforms comparison sorting on large
+// batches. Small buckets are finished with a comparison sort.
+func RadixSort(s []UUID) {
+	radixSort(s, 0)
+}
+
+func radixSort(s []UUID, d int) {
+	for ; d < 16; d++ {
+		if len(s) < radixCutoff {
+			slices.SortFunc(s, UUID.Compare)
+			return
+		}
+
+		var count [256]int
+		for i := range s {
+			count[s[i][d]]++
+		}
+		// A byte shared by every element, such as a V7 timestamp prefix or
+		// the version nibble, needs no pass.
+		if count[s[0][d]] == len(s) {
+			continue
+		}
+
+		var next, end [256]int
+		sum := 0
+		for b := range 256 {
+			next[b] = sum
+			sum += count[b]
+			end[b] = sum
+		}
+		for b := range 256 {
+			for next[b] < end[b] {
+				c := s[next[b]][d]
+				if int(c) == b {
+					next[b]++
+					continue
+				}
+				s[next[b]], s[next[c]] = s[next[c]], s[next[b]]
+				next[c]++
+			}
+		}
+
+		start := 0
+		for b := range 256 {
+			if end[b]-start > 1 {
+				radixSort(s[start:end[b]], d+1)
+			}
+			start = end[b]
+		}
+		return
+	}
+}
+
+// IsSorted reports whether s is sorted in increasing Compare order.
+func IsSorted(s []UUID) bool {
+	return slices.IsSortedFunc(s, UUID.Compare)
+}
+
+// BinarySearch searches for u in the sorted slice s and returns the
+// position where u is found, or the position where it would be inserted,
+// and whether it was found.
+func BinarySearch(s []UUID, u UUID) (int, bool) {
+	return slices.BinarySearchFunc(s, u, UUID.Compare)
+}
+
+// Dedup removes consecutive duplicates from the sorted slice s, in place,
+// and returns the shortened slice.
+func Dedup(s []UUID) []UUID {
+	return slices.Compact(s)
+}
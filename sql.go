@@ -14,28 +14,167 @@ func (u UUID) Value() (driver.Value, error) {
 	return u.String(), nil
 }
 
+// ScanError is returned by Scan when the source value cannot be converted
+// to a UUID. The receiver of Scan is left unchanged.
+type ScanError struct {
+	Src any   // value passed to Scan
+	Err error // parse or length error, if any
+}
+
+func (e *ScanError) Error() string {
+	var val string
+	switch src := e.Src.(type) {
+	case nil:
+		return "uuid: cannot scan NULL into UUID, use NullUUID"
+	case string, []byte, sql.RawBytes:
+		val = fmt.Sprintf("%q", src)
+	default:
+		val = fmt.Sprintf("%v", src)
+	}
+	if e.Err != nil {
+		return fmt.Sprintf("uuid: cannot scan %T %s into UUID: %v", e.Src, val, e.Err)
+	}
+	return fmt.Sprintf("uuid: cannot scan %T %s into UUID", e.Src, val)
+}
+
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// rawBinary returns the 16 bytes of a binary Scan source, accepting
+// []byte, sql.RawBytes and [16]byte as returned by various drivers.
+func rawBinary(src any) ([]byte, bool) {
+	switch src := src.(type) {
+	case []byte:
+		return src, len(src) == 16
+	case sql.RawBytes:
+		return src, len(src) == 16
+	case [16]byte:
+		return src[:], true
+	}
+	return nil, false
+}
+
 // Scan implements the sql.Scanner interface.
-// A 16-byte slice will be handled by UnmarshalBinary,
-// while a longer byte slice or a string will be handled by UnmarshalText.
+// A 16-byte slice or array is copied as raw bytes, while a longer byte
+// slice or a string is parsed as UUID text. On error a *ScanError is
+// returned and u is left unchanged.
 func (u *UUID) Scan(src any) error {
-	switch src := src.(type) {
-	case UUID: // support gorm convert from UUID to NullUUID
-		*u = src
+	if b, ok := rawBinary(src); ok {
+		copy(u[:], b)
 		return nil
+	}
 
+	var uu UUID
+	switch s := src.(type) {
+	case UUID: // support gorm convert from UUID to NullUUID
+		uu = s
+	case *UUID:
+		if s == nil {
+			return &ScanError{Src: src}
+		}
+		uu = *s
 	case []byte:
-		if len(src) == 16 {
-			return u.UnmarshalBinary(src)
+		if err := parse(s, &uu); err != nil {
+			return &ScanError{Src: src, Err: err}
+		}
+	case sql.RawBytes:
+		if err := parse(s, &uu); err != nil {
+			return &ScanError{Src: src, Err: err}
 		}
-		return u.UnmarshalText(src)
-
 	case string:
-		uu, err := Parse(src)
-		*u = uu
-		return err
+		if err := parse(stringBytes(s), &uu); err != nil {
+			return &ScanError{Src: src, Err: err}
+		}
+	default:
+		return &ScanError{Src: src}
 	}
+	*u = uu
+	return nil
+}
+
+var _ driver.Valuer = Binary{}
+var _ sql.Scanner = (*Binary)(nil)
+var _ driver.Valuer = Text{}
+var _ sql.Scanner = (*Text)(nil)
+
+// Binary is a UUID that is stored in the database as 16 raw bytes,
+// e.g. in BINARY(16) columns. Scan accepts both binary and text.
+type Binary UUID
+
+// String returns the canonical string form of the UUID.
+func (u Binary) String() string {
+	return UUID(u).String()
+}
+
+// Value implements the driver.Valuer interface.
+func (u Binary) Value() (driver.Value, error) {
+	return UUID(u).Bytes(), nil
+}
 
-	return fmt.Errorf("%s %T to UUID", "uuid: cannot convert", src)
+// Scan implements the sql.Scanner interface.
+func (u *Binary) Scan(src any) error {
+	return (*UUID)(u).Scan(src)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u Binary) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (u *Binary) UnmarshalText(b []byte) error {
+	return (*UUID)(u).UnmarshalText(b)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u Binary) MarshalJSON() ([]byte, error) {
+	return UUID(u).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (u *Binary) UnmarshalJSON(b []byte) error {
+	return (*UUID)(u).UnmarshalJSON(b)
+}
+
+// Text is a UUID that is stored in the database as its 36-character
+// canonical string. This is the same representation UUID itself uses;
+// Text makes the choice explicit at the column's Go type.
+type Text UUID
+
+// String returns the canonical string form of the UUID.
+func (u Text) String() string {
+	return UUID(u).String()
+}
+
+// Value implements the driver.Valuer interface.
+func (u Text) Value() (driver.Value, error) {
+	return UUID(u).Value()
+}
+
+// Scan implements the sql.Scanner interface.
+func (u *Text) Scan(src any) error {
+	return (*UUID)(u).Scan(src)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u Text) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (u *Text) UnmarshalText(b []byte) error {
+	return (*UUID)(u).UnmarshalText(b)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u Text) MarshalJSON() ([]byte, error) {
+	return UUID(u).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (u *Text) UnmarshalJSON(b []byte) error {
+	return (*UUID)(u).UnmarshalJSON(b)
 }
 
 // NullUUID can be used with the standard sql package to represent a
@@ -62,8 +201,17 @@ func (u *NullUUID) Scan(src interface{}) error {
 	}
 
 	// Delegate to UUID Scan function
-	u.Valid = true
-	return u.UUID.Scan(src)
+	var uu UUID
+	if err := uu.Scan(src); err != nil {
+		return err
+	}
+	u.UUID, u.Valid = uu, true
+	return nil
+}
+
+// IsZero reports whether u is NULL, so that `omitzero` drops invalid values.
+func (u NullUUID) IsZero() bool {
+	return !u.Valid
 }
 
 var nullJSON = []byte("null")
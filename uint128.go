@@ -0,0 +1,102 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// The methods in this file treat a UUID as a 128-bit unsigned integer in
+// big-endian byte order, the same order Compare uses. Arithmetic wraps
+// around modulo 2^128: Max.Next() is NilUUID and NilUUID.Prev() is Max.
+// Overflow can be detected by comparing the result with the receiver, e.g.
+// u.Add(n).Compare(u) < 0 reports that u+n wrapped.
+
+// Uint128 returns the high and low 64 bits of u.
+func (u UUID) Uint128() (hi, lo uint64) {
+	return binary.BigEndian.Uint64(u[0:8]), binary.BigEndian.Uint64(u[8:16])
+}
+
+// FromUint128 returns the UUID whose high and low 64 bits are hi and lo.
+func FromUint128(hi, lo uint64) UUID {
+	var u UUID
+	binary.BigEndian.PutUint64(u[0:8], hi)
+	binary.BigEndian.PutUint64(u[8:16], lo)
+	return u
+}
+
+// Next returns u+1. Max.Next() wraps around to NilUUID.
+func (u UUID) Next() UUID {
+	return u.Add(1)
+}
+
+// Prev returns u-1. NilUUID.Prev() wraps around to Max.
+func (u UUID) Prev() UUID {
+	return u.Sub(1)
+}
+
+// Add returns u+n, wrapping around modulo 2^128.
+func (u UUID) Add(n uint64) UUID {
+	hi, lo := u.Uint128()
+	lo, carry := bits.Add64(lo, n, 0)
+	hi, _ = bits.Add64(hi, 0, carry)
+	return FromUint128(hi, lo)
+}
+
+// Sub returns u-n, wrapping around modulo 2^128.
+func (u UUID) Sub(n uint64) UUID {
+	hi, lo := u.Uint128()
+	lo, borrow := bits.Sub64(lo, n, 0)
+	hi, _ = bits.Sub64(hi, 0, borrow)
+	return FromUint128(hi, lo)
+}
+
+// Xor returns the bitwise exclusive or of u and v.
+func (u UUID) Xor(v UUID) UUID {
+	uHi, uLo := u.Uint128()
+	vHi, vLo := v.Uint128()
+	return FromUint128(uHi^vHi, uLo^vLo)
+}
+
+// BigInt returns u as a newly allocated non-negative big.Int.
+func (u UUID) BigInt() *big.Int {
+	return new(big.Int).SetBytes(u[:])
+}
+
+// FromBigInt returns the UUID equal to x.
+// It will return an error if x is negative or doesn't fit in 128 bits.
+func FromBigInt(x *big.Int) (UUID, error) {
+	var u UUID
+	if x.Sign() < 0 || x.BitLen() > 128 {
+		return u, fmt.Errorf("uuid: integer out of the 128-bit unsigned range: %v", x)
+	}
+	x.FillBytes(u[:])
+	return u, nil
+}
+
+// add128 returns u+v, wrapping around modulo 2^128.
+func (u UUID) add128(v UUID) UUID {
+	uHi, uLo := u.Uint128()
+	vHi, vLo := v.Uint128()
+	lo, carry := bits.Add64(uLo, vLo, 0)
+	hi, _ := bits.Add64(uHi, vHi, carry)
+	return FromUint128(hi, lo)
+}
+
+// sub128 returns u-v, wrapping around modulo 2^128.
+func (u UUID) sub128(v UUID) UUID {
+	uHi, uLo := u.Uint128()
+	vHi, vLo := v.Uint128()
+	lo, borrow := bits.Sub64(uLo, vLo, 0)
+	hi, _ := bits.Sub64(uHi, vHi, borrow)
+	return FromUint128(hi, lo)
+}
+
+// divmod returns u/n and u%n.
+func (u UUID) divmod(n uint64) (UUID, uint64) {
+	hi, lo := u.Uint128()
+	qHi, r := hi/n, hi%n
+	qLo, r := bits.Div64(r, lo, n)
+	return FromUint128(qHi, qLo), r
+}
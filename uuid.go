@@ -4,6 +4,7 @@ package uuid
 
 import (
 	"encoding/binary"
+	"fmt"
 	"time"
 	"unsafe"
 )
@@ -20,7 +21,7 @@ const (
 	V5      // Version 5 (namespace name-based) [no implement]
 	V6      // Version 6 (k-sortable timestamp and random data, field-compatible with v1) [no implement]
 	V7      // Version 7 (k-sortable timestamp and random data)
-	_       // Version 8 (k-sortable timestamp, meant for custom implementations) [not implemented]
+	V8      // Version 8 (custom, vendor-specific layout)
 )
 
 // NilUUID is the nil UUID, as specified in RFC-9562, that has all 128 bits set to zero.
@@ -75,6 +76,22 @@ func NewV7() (UUID, error) {
 	return defaultGen.NewV7()
 }
 
+// NewV8SQLServer generates a time-ordered UUID whose layout is ascending
+// under SQL Server's uniqueidentifier ordering (see CompareSQLServer), so
+// that clustered indexes are appended to instead of fragmented.
+//
+// It carries the same fields as NewV7, rearranged; since the timestamp is
+// no longer in the leading bits it is labelled as version 8 (custom).
+//
+// Layout (byte positions):
+//
+//	10..15  unix_ts_ms, big-endian
+//	8..9    variant and 14 random bits
+//	0..7    version and 60 random bits
+func NewV8SQLServer() (UUID, error) {
+	return defaultGen.NewV8SQLServer()
+}
+
 // func NewV4Rand(rand io.Reader) UUID
 // func NewV7AtTime(t time.Time) UUID
 // func NewV7AtTimeRand(t time.Time, rand io.Reader) UUID
@@ -87,7 +104,9 @@ func (u UUID) Version() byte {
 // Must is a helper that wraps a call to a function returning (UUID, error) and panics
 // if the error is non-nil. It is intended for use in variable initializations such as
 //
-//	var packageUUID = uuid.MustUUID(uuid.FromString("123e4567-e89b-12d3-a456-426655440000"))
+//	var packageUUID = uuid.MustUUID(uuid.Parse("123e4567-e89b-12d3-a456-426655440000"))
+//
+// For parsing string literals, MustParse is shorter.
 func MustUUID(u UUID, err error) UUID {
 	if err != nil {
 		panic(err)
@@ -145,6 +164,84 @@ func (u UUID) String() string {
 	return unsafe.String(&buf[0], 36)
 }
 
+// Format implements the fmt.Formatter interface. The following verbs are
+// supported:
+//
+//	%s, %v  canonical form:  6ba7b810-9dad-11d1-80b4-00c04fd430c8
+//	%+v     URN form:        urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8
+//	%#v     Go syntax:       uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+//	%x      hash-like form:  6ba7b8109dad11d180b400c04fd430c8
+//	%X      upper-case hash: 6BA7B8109DAD11D180B400C04FD430C8
+//	%q      quoted canonical form
+//
+// Width and the '-' flag are honored for padding.
+func (u UUID) Format(f fmt.State, verb rune) {
+	var buf [56]byte
+	var b []byte
+
+	switch verb {
+	case 'v':
+		switch {
+		case f.Flag('#'):
+			b = append(buf[:0], `uuid.MustParse("`...)
+			b = b[:len(b)+36]
+			encodeCanonical(b[len(b)-36:], u)
+			b = append(b, `")`...)
+		case f.Flag('+'):
+			b = append(buf[:0], "urn:uuid:"...)
+			b = b[:len(b)+36]
+			encodeCanonical(b[len(b)-36:], u)
+		default:
+			b = buf[:36]
+			encodeCanonical(b, u)
+		}
+	case 's':
+		b = buf[:36]
+		encodeCanonical(b, u)
+	case 'q':
+		b = buf[:38]
+		b[0], b[37] = '"', '"'
+		encodeCanonical(b[1:37], u)
+	case 'x', 'X':
+		b = buf[:32]
+		for i, c := range u {
+			t := hexTable[c]
+			b[i*2], b[i*2+1] = t[0], t[1]
+		}
+		if verb == 'X' {
+			for i, c := range b {
+				if c >= 'a' {
+					b[i] = c - ('a' - 'A')
+				}
+			}
+		}
+	default:
+		fmt.Fprintf(f, "%%!%c(uuid.UUID=", verb)
+		b = buf[:37]
+		encodeCanonical(b, u)
+		b[36] = ')'
+		f.Write(b)
+		return
+	}
+
+	w, ok := f.Width()
+	if !ok || w <= len(b) {
+		f.Write(b)
+		return
+	}
+	pad := make([]byte, w-len(b))
+	for i := range pad {
+		pad[i] = ' '
+	}
+	if f.Flag('-') {
+		f.Write(b)
+		f.Write(pad)
+		return
+	}
+	f.Write(pad)
+	f.Write(b)
+}
+
 // Encode serializes the UUID into the canonical 36-character string format (RFC 9562)
 // and writes it into the provided buffer.
 //
@@ -196,6 +293,12 @@ func (u UUID) IsNilUUID() bool {
 	return u == NilUUID
 }
 
+// IsZero reports whether u is the nil UUID. It allows the `omitzero`
+// struct tag option of the encoding packages to drop unset UUIDs.
+func (u UUID) IsZero() bool {
+	return u == NilUUID
+}
+
 // Equal returns true if this UUID equals another UUID by value.
 func (u *UUID) Equal(another *UUID) bool {
 	if u == another {
@@ -233,15 +336,105 @@ func (u UUID) Compare(v UUID) int {
 	return 0
 }
 
-// Milliseconds 直接提取 UUIDv7 前 48 位时间戳并返回毫秒数。
-func (u UUID) Milliseconds() int64 {
-	hi := binary.BigEndian.Uint64(u[0:8])
-	return int64(hi >> 16)
+// sqlServerOrder lists the byte indexes of a UUID from most to least
+// significant as compared by SQL Server for uniqueidentifier values.
+//
+// SQL Server stores a GUID in mixed-endian layout and compares the stored
+// bytes in the order 10..15, 8..9, 6..7, 4..5, 0..3. Translated back to the
+// big-endian layout of UUID, the last three groups are walked backwards.
+var sqlServerOrder = [16]byte{10, 11, 12, 13, 14, 15, 8, 9, 7, 6, 5, 4, 3, 2, 1, 0}
+
+// CompareSQLServer compares u and v the way SQL Server orders
+// uniqueidentifier columns, returning -1, 0 or +1. Use it instead of
+// Compare when the database, not Go, decides the index order.
+func (u UUID) CompareSQLServer(v UUID) int {
+	for _, i := range sqlServerOrder {
+		if u[i] != v[i] {
+			if u[i] < v[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// gregorianToUnix is the number of 100-nanosecond intervals between the
+// Gregorian epoch (1582-10-15 00:00:00 UTC) used by V1 and V6 and the
+// Unix epoch.
+const gregorianToUnix = 122192928000000000
+
+// isRFC9562 reports whether u has the RFC-9562 variant and version v.
+func (u UUID) isRFC9562(v byte) bool {
+	return u.Variant() == VariantRFC9562 && u.Version() == v
+}
+
+// gregorianTicks returns the 60-bit timestamp of a V1 or V6 UUID, counted
+// in 100-nanosecond intervals since the Gregorian epoch.
+func (u UUID) gregorianTicks() (uint64, bool) {
+	switch {
+	case u.isRFC9562(V1):
+		// time_low(32) time_mid(16) ver(4) time_high(12)
+		low := uint64(binary.BigEndian.Uint32(u[0:4]))
+		mid := uint64(binary.BigEndian.Uint16(u[4:6]))
+		high := uint64(binary.BigEndian.Uint16(u[6:8]) & 0x0FFF)
+		return high<<48 | mid<<32 | low, true
+	case u.isRFC9562(V6):
+		// time_high(32) time_mid(16) ver(4) time_low(12)
+		high := uint64(binary.BigEndian.Uint32(u[0:4]))
+		mid := uint64(binary.BigEndian.Uint16(u[4:6]))
+		low := uint64(binary.BigEndian.Uint16(u[6:8]) & 0x0FFF)
+		return high<<28 | mid<<12 | low, true
+	}
+	return 0, false
+}
+
+// Milliseconds returns the Unix timestamp of the UUID in milliseconds.
+// It decodes the Gregorian 100ns timestamp of V1 and V6 and the Unix
+// millisecond timestamp of V7. For other versions, or if the variant is
+// not RFC-9562, it returns 0 and false.
+func (u UUID) Milliseconds() (int64, bool) {
+	if u.isRFC9562(V7) {
+		hi := binary.BigEndian.Uint64(u[0:8])
+		return int64(hi >> 16), true
+	}
+	if ticks, ok := u.gregorianTicks(); ok {
+		return (int64(ticks) - gregorianToUnix) / 10_000, true
+	}
+	return 0, false
+}
+
+// Time returns the timestamp embedded in a V1, V6 or V7 UUID. V1 and V6
+// have 100-nanosecond precision, V7 millisecond precision. For other
+// versions, or if the variant is not RFC-9562, it returns the zero Time
+// and false.
+func (u UUID) Time() (time.Time, bool) {
+	if u.isRFC9562(V7) {
+		ms, _ := u.Milliseconds()
+		return time.UnixMilli(ms), true
+	}
+	if ticks, ok := u.gregorianTicks(); ok {
+		t := int64(ticks) - gregorianToUnix
+		return time.Unix(t/10_000_000, t%10_000_000*100), true
+	}
+	return time.Time{}, false
 }
 
-// Time 返回 UUIDv7 的时间戳对象。
-func (u UUID) Time() time.Time {
-	return time.UnixMilli(u.Milliseconds())
+// ClockSequence returns the 14-bit clock sequence of a V1 or V6 UUID.
+func (u UUID) ClockSequence() (uint16, bool) {
+	if !u.isRFC9562(V1) && !u.isRFC9562(V6) {
+		return 0, false
+	}
+	return binary.BigEndian.Uint16(u[8:10]) & 0x3FFF, true
+}
+
+// NodeID returns the 48-bit node field of a V1 or V6 UUID, usually an IEEE
+// 802 MAC address or random bits.
+func (u UUID) NodeID() ([6]byte, bool) {
+	if !u.isRFC9562(V1) && !u.isRFC9562(V6) {
+		return [6]byte{}, false
+	}
+	return [6]byte(u[10:16]), true
 }
 
 // Variant returns the UUID layout variant.
@@ -1,6 +1,9 @@
 package uuid
 
 import (
+	"bytes"
+	"runtime"
+	"slices"
 	"testing"
 )
 
@@ -21,3 +24,166 @@ func BenchmarkAppendTo(b *testing.B) {
 		_ = u.Encode(buf)
 	}
 }
+
+func BenchmarkParse(b *testing.B) {
+	const s = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = Parse(s)
+	}
+}
+
+func benchUUIDs(n int) []UUID {
+	ids := make([]UUID, n)
+	for i := range ids {
+		ids[i], _ = defaultGen.NewV7()
+	}
+	return ids
+}
+
+func BenchmarkParseMany(b *testing.B) {
+	ids := benchUUIDs(1024)
+	src := make([]string, len(ids))
+	for i, u := range ids {
+		src[i] = u.String()
+	}
+	dst := make([]UUID, 0, len(src))
+	b.SetBytes(int64(36 * len(src)))
+	b.ReportAllocs()
+	for b.Loop() {
+		dst, _ = ParseMany(dst[:0], src)
+	}
+}
+
+func BenchmarkEncodeMany(b *testing.B) {
+	ids := benchUUIDs(1024)
+	buf := make([]byte, 36*len(ids))
+	b.SetBytes(int64(len(buf)))
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = EncodeMany(buf, ids)
+	}
+}
+
+func benchRandomUUIDs(n int) []UUID {
+	ids := make([]UUID, n)
+	for i := range ids {
+		ids[i], _ = defaultGen.NewV4()
+	}
+	return ids
+}
+
+func BenchmarkRadixSort(b *testing.B) {
+	src := benchRandomUUIDs(1 << 16)
+	s := make([]UUID, len(src))
+	for b.Loop() {
+		copy(s, src)
+		RadixSort(s)
+	}
+}
+
+func BenchmarkSortFunc(b *testing.B) {
+	src := benchRandomUUIDs(1 << 16)
+	s := make([]UUID, len(src))
+	for b.Loop() {
+		copy(s, src)
+		slices.SortFunc(s, UUID.Compare)
+	}
+}
+
+func BenchmarkEncodeSorted(b *testing.B) {
+	ids := benchUUIDs(1 << 16)
+	RadixSort(ids)
+	var buf bytes.Buffer
+	b.SetBytes(int64(16 * len(ids)))
+	for b.Loop() {
+		buf.Reset()
+		_ = EncodeSorted(&buf, ids)
+	}
+	// Size relative to concatenated MarshalBinary output (16 bytes each).
+	b.ReportMetric(float64(buf.Len())/float64(16*len(ids)), "ratio")
+}
+
+func BenchmarkDecodeSorted(b *testing.B) {
+	ids := benchUUIDs(1 << 16)
+	RadixSort(ids)
+	var buf bytes.Buffer
+	_ = EncodeSorted(&buf, ids)
+	data := buf.Bytes()
+	b.SetBytes(int64(16 * len(ids)))
+	for b.Loop() {
+		_, _ = DecodeSorted(bytes.NewReader(data))
+	}
+}
+
+func BenchmarkSetChurn(b *testing.B) {
+	// 3/4 of 1<<17 slots: the table is exactly at its load factor.
+	const n = 3 << 15
+	ids := benchRandomUUIDs(2 * n)
+	var s Set
+	for _, u := range ids[:n] {
+		s.Add(u)
+	}
+	i := 0
+	for b.Loop() {
+		s.Remove(ids[i%len(ids)])
+		s.Add(ids[(i+n)%len(ids)])
+		i++
+	}
+}
+
+// benchBytesPerEntry reports the heap retained by build(ids) per UUID.
+func benchBytesPerEntry(b *testing.B, ids []UUID, build func([]UUID) any) {
+	var total int64
+	var before, after runtime.MemStats
+	for range b.N {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		v := build(ids)
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		runtime.KeepAlive(v)
+		total += int64(after.HeapAlloc) - int64(before.HeapAlloc)
+	}
+	b.ReportMetric(float64(total)/float64(b.N)/float64(len(ids)), "B/entry")
+}
+
+func BenchmarkSetMemory(b *testing.B) {
+	ids := benchUUIDs(3_000_000)
+	b.Run("Set", func(b *testing.B) {
+		benchBytesPerEntry(b, ids, func(ids []UUID) any {
+			var s Set
+			for _, u := range ids {
+				s.Add(u)
+			}
+			return &s
+		})
+	})
+	b.Run("map", func(b *testing.B) {
+		benchBytesPerEntry(b, ids, func(ids []UUID) any {
+			m := make(map[UUID]struct{})
+			for _, u := range ids {
+				m[u] = struct{}{}
+			}
+			return m
+		})
+	})
+	b.Run("Map[int64]", func(b *testing.B) {
+		benchBytesPerEntry(b, ids, func(ids []UUID) any {
+			var m Map[int64]
+			for i, u := range ids {
+				m.Put(u, int64(i))
+			}
+			return &m
+		})
+	})
+	b.Run("map[int64]", func(b *testing.B) {
+		benchBytesPerEntry(b, ids, func(ids []UUID) any {
+			m := make(map[UUID]int64)
+			for i, u := range ids {
+				m[u] = int64(i)
+			}
+			return m
+		})
+	})
+}
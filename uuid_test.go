@@ -1,9 +1,20 @@
 package uuid
 
 import (
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
 	"os"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 // TestData 对应 JSON 中的结构
@@ -72,3 +83,1099 @@ func TestUUID_String_CrossValidation_Pressure(t *testing.T) {
 
 	t.Logf("Pressure Test Complete: Validated %d UUIDs (%d cases * %d iterations)", totalChecks, len(cases), iterations)
 }
+
+func TestUUID_Format(t *testing.T) {
+	u := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	cases := []struct {
+		format string
+		want   string
+	}{
+		{"%s", "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{"%v", "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{"%+v", "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{"%#v", `uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")`},
+		{"%x", "6ba7b8109dad11d180b400c04fd430c8"},
+		{"%X", "6BA7B8109DAD11D180B400C04FD430C8"},
+		{"%q", `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`},
+		{"%38s", "  6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{"%-38s|", "6ba7b810-9dad-11d1-80b4-00c04fd430c8  |"},
+		{"%d", "%!d(uuid.UUID=6ba7b810-9dad-11d1-80b4-00c04fd430c8)"},
+	}
+	for _, tc := range cases {
+		if got := fmt.Sprintf(tc.format, u); got != tc.want {
+			t.Errorf("Sprintf(%q) = %s, want %s", tc.format, got, tc.want)
+		}
+	}
+}
+
+func TestUUID_JSON(t *testing.T) {
+	u := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	data, err := json.Marshal(u)
+	if err != nil || string(data) != `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"` {
+		t.Errorf("json.Marshal() = %s, %v", data, err)
+	}
+
+	for _, in := range []string{
+		`"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`,
+		`"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"`,
+		`"\u0036ba7b810-9dad-11d1-80b4-00c04fd430c8"`,
+		`"urn\u003auuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8"`,
+	} {
+		var got UUID
+		if err := json.Unmarshal([]byte(in), &got); err != nil || got != u {
+			t.Errorf("json.Unmarshal(%s) = %s, %v, want %s", in, got, err, u)
+		}
+	}
+
+	got := u
+	if err := json.Unmarshal([]byte("null"), &got); err != nil || got != u {
+		t.Errorf("json.Unmarshal(null) = %s, %v, want receiver unchanged", got, err)
+	}
+
+	for _, in := range []string{`123`, `true`, `["6ba7b810-9dad-11d1-80b4-00c04fd430c8"]`, `"6ba7b810"`} {
+		var got UUID
+		if err := json.Unmarshal([]byte(in), &got); err == nil {
+			t.Errorf("json.Unmarshal(%s) = %s, want error", in, got)
+		}
+	}
+	if err := got.UnmarshalJSON([]byte(`123`)); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("UnmarshalJSON(123) error = %v, want %v", err, ErrInvalidFormat)
+	}
+
+	// The receiver is left unchanged on error, not partially decoded.
+	const bad = "6ba7b810-9dad-11d1-80b4-00c04fd43zzz"
+	got = Max
+	if err := json.Unmarshal([]byte(`"`+bad+`"`), &got); err == nil || got != Max {
+		t.Errorf("json.Unmarshal(%q) = %s, %v, want error and receiver unchanged", bad, got, err)
+	}
+	if err := got.UnmarshalJSON([]byte(`"` + bad + `"`)); err == nil || got != Max {
+		t.Errorf("UnmarshalJSON(%q) = %s, %v, want error and receiver unchanged", bad, got, err)
+	}
+	if err := got.UnmarshalText([]byte(bad)); err == nil || got != Max {
+		t.Errorf("UnmarshalText(%q) = %s, %v, want error and receiver unchanged", bad, got, err)
+	}
+}
+
+func TestOmitZero(t *testing.T) {
+	type row struct {
+		ID   UUID     `json:"id,omitzero"`
+		Ref  NullUUID `json:"ref,omitzero"`
+		Prev UUID     `json:"prev"`
+	}
+	data, err := json.Marshal(row{})
+	if err != nil || string(data) != `{"prev":"00000000-0000-0000-0000-000000000000"}` {
+		t.Errorf("json.Marshal(zero row) = %s, %v", data, err)
+	}
+
+	u := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	data, err = json.Marshal(row{ID: u, Ref: NullUUID{UUID: u, Valid: true}})
+	const want = `{"id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","ref":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","prev":"00000000-0000-0000-0000-000000000000"}`
+	if err != nil || string(data) != want {
+		t.Errorf("json.Marshal(row) = %s, %v, want %s", data, err, want)
+	}
+
+	// A valid NullUUID holding the nil UUID is not zero.
+	data, _ = json.Marshal(row{Ref: NullUUID{Valid: true}})
+	if !bytes.Contains(data, []byte(`"ref"`)) {
+		t.Errorf("json.Marshal(valid nil NullUUID) = %s, want ref present", data)
+	}
+}
+
+func TestNilAsNullAndEmpty(t *testing.T) {
+	u := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	const quoted = `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`
+
+	cases := []struct {
+		v       any // NilAsNull or NilAsEmpty
+		nilJSON string
+	}{
+		{NilAsNull{}, `null`},
+		{NilAsEmpty{}, `""`},
+	}
+	for _, tc := range cases {
+		data, err := json.Marshal(tc.v)
+		if err != nil || string(data) != tc.nilJSON {
+			t.Errorf("json.Marshal(%T(nil)) = %s, %v, want %s", tc.v, data, err, tc.nilJSON)
+		}
+	}
+
+	var n NilAsNull
+	if data, _ := json.Marshal(NilAsNull(u)); string(data) != quoted {
+		t.Errorf("json.Marshal(NilAsNull) = %s, want %s", data, quoted)
+	}
+	if err := json.Unmarshal([]byte(quoted), &n); err != nil || UUID(n) != u {
+		t.Errorf("NilAsNull from %s = %s, %v", quoted, n, err)
+	}
+	if err := json.Unmarshal([]byte(`null`), &n); err != nil || UUID(n) != NilUUID {
+		t.Errorf("NilAsNull from null = %s, %v, want nil UUID", n, err)
+	}
+
+	e := NilAsEmpty(u)
+	if err := json.Unmarshal([]byte(`""`), &e); err != nil || UUID(e) != NilUUID {
+		t.Errorf(`NilAsEmpty from "" = %s, %v, want nil UUID`, e, err)
+	}
+	if data, _ := json.Marshal(NilAsEmpty(u)); string(data) != quoted {
+		t.Errorf("json.Marshal(NilAsEmpty) = %s, want %s", data, quoted)
+	}
+	if err := json.Unmarshal([]byte(quoted), &e); err != nil || UUID(e) != u {
+		t.Errorf("NilAsEmpty from %s = %s, %v", quoted, e, err)
+	}
+	if err := json.Unmarshal([]byte(`null`), &e); err != nil || UUID(e) != NilUUID {
+		t.Errorf("NilAsEmpty from null = %s, %v, want nil UUID", e, err)
+	}
+
+	// Text has no null, so both types encode the nil UUID as empty text.
+	for _, m := range []interface{ MarshalText() ([]byte, error) }{NilAsNull{}, NilAsEmpty{}} {
+		if text, err := m.MarshalText(); len(text) != 0 || err != nil {
+			t.Errorf("%T.MarshalText() = %q, %v, want empty", m, text, err)
+		}
+	}
+	if text, _ := NilAsNull(u).MarshalText(); string(text) != u.String() {
+		t.Errorf("NilAsNull.MarshalText() = %s, want %s", text, u)
+	}
+	n = NilAsNull(u)
+	if err := n.UnmarshalText(nil); err != nil || UUID(n) != NilUUID {
+		t.Errorf("NilAsNull.UnmarshalText(empty) = %s, %v, want nil UUID", n, err)
+	}
+	e = NilAsEmpty(u)
+	if err := e.UnmarshalText(nil); err != nil || UUID(e) != NilUUID {
+		t.Errorf("NilAsEmpty.UnmarshalText(empty) = %s, %v, want nil UUID", e, err)
+	}
+	if err := e.UnmarshalText([]byte(u.String())); err != nil || UUID(e) != u {
+		t.Errorf("NilAsEmpty.UnmarshalText(%s) = %s, %v", u, e, err)
+	}
+}
+
+func TestNull(t *testing.T) {
+	type UserID UUID
+	id := UserID(MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+	const str = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
+	for _, src := range []any{str, []byte(str), id[:]} {
+		var n Null[UserID]
+		if err := n.Scan(src); err != nil || !n.Valid || n.V != id {
+			t.Errorf("Scan(%T) = %+v, %v, want %s", src, n, err, str)
+		}
+	}
+	n := NullOf(id)
+	if err := n.Scan(nil); err != nil || n.Valid || n.V != (UserID{}) {
+		t.Errorf("Scan(nil) = %+v, %v, want NULL", n, err)
+	}
+	if err := n.Scan(42); err == nil || n.Valid {
+		t.Errorf("Scan(42) = %+v, %v, want error and receiver unchanged", n, err)
+	}
+
+	if v, err := n.Value(); v != nil || err != nil {
+		t.Errorf("Value() of NULL = %v, %v, want nil", v, err)
+	}
+	if v, err := NullOf(id).Value(); v != str || err != nil {
+		t.Errorf("Value() = %v, %v, want %s", v, err, str)
+	}
+
+	if data, _ := json.Marshal(Null[UserID]{}); string(data) != "null" {
+		t.Errorf("json.Marshal(NULL) = %s, want null", data)
+	}
+	data, _ := json.Marshal(NullOf(id))
+	if string(data) != `"`+str+`"` {
+		t.Errorf("json.Marshal() = %s, want %q", data, str)
+	}
+	var back Null[UserID]
+	if err := json.Unmarshal(data, &back); err != nil || back != NullOf(id) {
+		t.Errorf("json.Unmarshal(%s) = %+v, %v", data, back, err)
+	}
+	if err := json.Unmarshal([]byte("null"), &back); err != nil || back.Valid {
+		t.Errorf("json.Unmarshal(null) = %+v, %v, want NULL", back, err)
+	}
+
+	if text, err := (Null[UserID]{}).MarshalText(); len(text) != 0 || err != nil {
+		t.Errorf("MarshalText() of NULL = %q, %v, want empty", text, err)
+	}
+	back = NullOf(id)
+	if err := back.UnmarshalText(nil); err != nil || back.Valid {
+		t.Errorf("UnmarshalText(empty) = %+v, %v, want NULL", back, err)
+	}
+	if err := back.UnmarshalText([]byte(str)); err != nil || back != NullOf(id) {
+		t.Errorf("UnmarshalText(%s) = %+v, %v", str, back, err)
+	}
+
+	if !(Null[UserID]{}).IsZero() || NullOf(UserID{}).IsZero() {
+		t.Error("IsZero() must report NULL only")
+	}
+	type row struct {
+		Owner Null[UserID] `json:"owner,omitzero"`
+	}
+	if data, _ := json.Marshal(row{}); string(data) != "{}" {
+		t.Errorf("json.Marshal(omitzero NULL) = %s, want {}", data)
+	}
+}
+
+// testWrapper checks that w keeps the text and JSON forms of UUID(w) and
+// decodes back from them.
+func testWrapper[W ~[16]byte, P interface {
+	*W
+	wrapper
+}](t *testing.T, w W) {
+	t.Helper()
+	u := UUID(w)
+	if got := P(&w).String(); got != u.String() {
+		t.Errorf("%T.String() = %s, want %s", w, got, u)
+	}
+
+	text, err := P(&w).MarshalText()
+	if err != nil || string(text) != u.String() {
+		t.Errorf("%T.MarshalText() = %s, %v, want %s", w, text, err, u)
+	}
+	var fromText W
+	if err := P(&fromText).UnmarshalText(text); err != nil || fromText != w {
+		t.Errorf("%T.UnmarshalText(%s) = %s, %v", w, text, UUID(fromText), err)
+	}
+
+	data, err := json.Marshal(w)
+	want, _ := json.Marshal(u)
+	if err != nil || string(data) != string(want) {
+		t.Errorf("json.Marshal(%T) = %s, %v, want %s", w, data, err, want)
+	}
+	var fromJSON W
+	if err := json.Unmarshal(data, &fromJSON); err != nil || fromJSON != w {
+		t.Errorf("json.Unmarshal(%s) into %T = %s, %v", data, w, UUID(fromJSON), err)
+	}
+}
+
+func TestGUIDBytes(t *testing.T) {
+	// Guid.Parse("00112233-4455-6677-8899-aabbccddeeff").ToByteArray() in .NET
+	guid := []byte{0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+	u := MustParse("00112233-4455-6677-8899-aabbccddeeff")
+
+	if got := u.ToGUIDBytes(); !bytes.Equal(got, guid) {
+		t.Errorf("ToGUIDBytes() = %x, want %x", got, guid)
+	}
+	got, err := FromGUIDBytes(guid)
+	if err != nil || got != u {
+		t.Errorf("FromGUIDBytes() = %s, %v, want %s", got, err, u)
+	}
+
+	var g GUID
+	if err := g.Scan(guid); err != nil || UUID(g) != u {
+		t.Errorf("GUID.Scan() = %s, %v, want %s", g, err, u)
+	}
+	testWrapper(t, g)
+}
+
+func TestBinaryText(t *testing.T) {
+	want := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	v, err := Binary(want).Value()
+	if b, ok := v.([]byte); err != nil || !ok || !bytes.Equal(b, want[:]) {
+		t.Errorf("Binary.Value() = %#v, %v, want 16 raw bytes", v, err)
+	}
+	v, err = Text(want).Value()
+	if err != nil || v != want.String() {
+		t.Errorf("Text.Value() = %#v, %v, want %q", v, err, want.String())
+	}
+
+	for _, src := range []any{want[:], want.String(), []byte(want.String())} {
+		var b Binary
+		if err := b.Scan(src); err != nil || UUID(b) != want {
+			t.Errorf("Binary.Scan(%T) = %s, %v, want %s", src, b, err, want)
+		}
+		var x Text
+		if err := x.Scan(src); err != nil || UUID(x) != want {
+			t.Errorf("Text.Scan(%T) = %s, %v, want %s", src, x, err, want)
+		}
+	}
+
+	testWrapper(t, Binary(want))
+	testWrapper(t, Text(want))
+}
+
+func TestCompareSQLServer(t *testing.T) {
+	// Each UUID has a single byte set; under SQL Server ordering the
+	// list below is ascending, from least to most significant byte.
+	order := []int{0, 1, 2, 3, 4, 5, 6, 7, 9, 8, 15, 14, 13, 12, 11, 10}
+	ids := make([]UUID, len(order))
+	for i, pos := range order {
+		ids[i][pos] = 1
+	}
+	for i := 1; i < len(ids); i++ {
+		if got := ids[i-1].CompareSQLServer(ids[i]); got != -1 {
+			t.Errorf("CompareSQLServer(%s, %s) = %d, want -1", ids[i-1], ids[i], got)
+		}
+		if got := ids[i].CompareSQLServer(ids[i-1]); got != 1 {
+			t.Errorf("CompareSQLServer(%s, %s) = %d, want 1", ids[i], ids[i-1], got)
+		}
+	}
+	if got := ids[0].CompareSQLServer(ids[0]); got != 0 {
+		t.Errorf("CompareSQLServer(%s, %s) = %d, want 0", ids[0], ids[0], got)
+	}
+
+	// Known example: within group 10..15 bytes compare left to right,
+	// while in group 0..3 the last byte is the most significant.
+	a := MustParse("01000000-0000-0000-0000-000000000000")
+	b := MustParse("00000001-0000-0000-0000-000000000000")
+	if a.CompareSQLServer(b) != -1 {
+		t.Errorf("expected %s < %s under SQL Server ordering", a, b)
+	}
+}
+
+func TestNewV8SQLServer(t *testing.T) {
+	var prev UUID
+	for i := range 5 {
+		u, err := NewV8SQLServer()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Version() != V8 || u.Variant() != VariantRFC9562 {
+			t.Fatalf("unexpected version %d or variant %d", u.Version(), u.Variant())
+		}
+		if i > 0 && prev.CompareSQLServer(u) != -1 {
+			t.Errorf("expected %s < %s under SQL Server ordering", prev, u)
+		}
+		prev = u
+		time.Sleep(2 * time.Millisecond)
+	}
+}
+
+func TestMySQLSwapped(t *testing.T) {
+	// Vectors from the MySQL reference manual for UUID_TO_BIN(uuid, 1).
+	cases := []struct {
+		uuid    string
+		swapped string
+	}{
+		{"6ccd780c-baba-1026-9564-5b8c656024db", "1026baba6ccd780c95645b8c656024db"},
+		{"00112233-4455-6677-8899-aabbccddeeff", "66774455001122338899aabbccddeeff"},
+	}
+	for _, tc := range cases {
+		u := MustParse(tc.uuid)
+		want, _ := hex.DecodeString(tc.swapped)
+
+		if got := u.ToMySQLSwapped(); !bytes.Equal(got, want) {
+			t.Errorf("ToMySQLSwapped(%s) = %x, want %x", u, got, want)
+		}
+		if got, err := FromMySQLSwapped(want); err != nil || got != u {
+			t.Errorf("FromMySQLSwapped(%x) = %s, %v, want %s", want, got, err, u)
+		}
+
+		var s MySQLSwapped
+		if err := s.Scan(want); err != nil || UUID(s) != u {
+			t.Errorf("MySQLSwapped.Scan(%x) = %s, %v, want %s", want, s, err, u)
+		}
+		v, _ := s.Value()
+		if !bytes.Equal(v.([]byte), want) {
+			t.Errorf("MySQLSwapped.Value() = %x, want %x", v, want)
+		}
+		testWrapper(t, s)
+	}
+}
+
+func TestNullUUIDs_Scan(t *testing.T) {
+	a := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	b := MustParse("6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+	want := NullUUIDs{{UUID: a, Valid: true}, {UUID: b, Valid: true}, {}}
+
+	var text NullUUIDs
+	if err := text.Scan(`{6ba7b810-9dad-11d1-80b4-00c04fd430c8,"6ba7b811-9dad-11d1-80b4-00c04fd430c8",NULL}`); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(text, want) {
+		t.Errorf("Scan(text) = %v, want %v", text, want)
+	}
+
+	var bin NullUUIDs
+	if err := bin.Scan(want.AppendPgBinary(nil)); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(bin, want) {
+		t.Errorf("Scan(binary) = %v, want %v", bin, want)
+	}
+
+	v, _ := want.Value()
+	if v != "{6ba7b810-9dad-11d1-80b4-00c04fd430c8,6ba7b811-9dad-11d1-80b4-00c04fd430c8,NULL}" {
+		t.Errorf("Value() = %v", v)
+	}
+
+	var strict UUIDs
+	if err := strict.Scan(v); err == nil {
+		t.Error("UUIDs.Scan() accepted a NULL element")
+	}
+
+	if err := bin.Scan(42); err == nil || err.Error() != "uuid: cannot convert int to NullUUIDs" {
+		t.Errorf("NullUUIDs.Scan(42) error = %v", err)
+	}
+}
+
+func TestUUID_Scan(t *testing.T) {
+	want := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	for _, src := range []any{
+		want,
+		&want,
+		[16]byte(want),
+		want[:],
+		sql.RawBytes(want[:]),
+		want.String(),
+		[]byte(want.String()),
+		sql.RawBytes(want.String()),
+	} {
+		var u UUID
+		if err := u.Scan(src); err != nil || u != want {
+			t.Errorf("Scan(%T) = %s, %v, want %s", src, u, err, want)
+		}
+	}
+
+	for _, src := range []any{nil, 42, "not-a-uuid", []byte{1, 2, 3}, (*UUID)(nil)} {
+		u := Max
+		err := u.Scan(src)
+		var se *ScanError
+		if !errors.As(err, &se) {
+			t.Errorf("Scan(%T) error = %v, want *ScanError", src, err)
+		}
+		if u != Max {
+			t.Errorf("Scan(%T) modified the receiver on failure: %s", src, u)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := []struct {
+		in     string
+		err    error
+		offset int
+	}{
+		{"6ba7b810", ErrInvalidLength, -1},
+		{"{6ba7b810-9dad-11d1-80b4-00c04fd430c8)", ErrInvalidFormat, 37},
+		{"urn:uuix:6ba7b810-9dad-11d1-80b4-00c04fd430c8", ErrInvalidFormat, 0},
+		{"urn\x1auuid\x1a6ba7b810-9dad-11d1-80b4-00c04fd430c8", ErrInvalidFormat, 0},
+		{"URN:UUID:6ba7b810-9dad-11d1-80b4-00c04fd430c8", ErrInvalidFormat, 0},
+		{"6ba7b810-9dad_11d1-80b4-00c04fd430c8", ErrInvalidFormat, 13},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430cZ", ErrInvalidChar, 35},
+		{"{6ba7b8109dad11d180b4X0c04fd430c8}", ErrInvalidChar, 21},
+	}
+	for _, tc := range cases {
+		_, err := Parse(tc.in)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("Parse(%q) error = %v, want %v", tc.in, err, tc.err)
+			continue
+		}
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("Parse(%q) error = %T, want *ParseError", tc.in, err)
+			continue
+		}
+		if pe.Input != tc.in || pe.Offset != tc.offset {
+			t.Errorf("Parse(%q) = {Input: %q, Offset: %d}, want offset %d", tc.in, pe.Input, pe.Offset, tc.offset)
+		}
+	}
+}
+
+func TestParser(t *testing.T) {
+	want := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	cases := []struct {
+		in      string
+		strict  bool
+		dflt    bool
+		lenient bool
+	}{
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", true, true, true},
+		{"6BA7B810-9DAD-11D1-80B4-00C04FD430C8", false, true, true},
+		{"6ba7b8109dad11d180b400c04fd430c8", false, true, true},
+		{"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}", false, true, true},
+		{"urn:uuid:6BA7B810-9DAD-11D1-80B4-00C04FD430C8", false, true, true},
+		{"URN:UUID:6BA7B810-9DAD-11D1-80B4-00C04FD430C8", false, false, true},
+		{"Urn:Uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8", false, false, true},
+		{"urn\x1auuid\x1a6ba7b810-9dad-11d1-80b4-00c04fd430c8", false, false, false},
+		{" urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8\n", false, false, true},
+	}
+	for _, tc := range cases {
+		for _, p := range []struct {
+			name   string
+			parser Parser
+			ok     bool
+		}{
+			{"StrictParser", StrictParser, tc.strict},
+			{"Parser{}", Parser{}, tc.dflt},
+			{"LenientParser", LenientParser, tc.lenient},
+		} {
+			u, err := p.parser.Parse(tc.in)
+			if p.ok && (err != nil || u != want) {
+				t.Errorf("%s.Parse(%q) = %s, %v, want %s", p.name, tc.in, u, err, want)
+			}
+			if !p.ok && (err == nil || u != NilUUID) {
+				t.Errorf("%s.Parse(%q) = %s, %v, want error", p.name, tc.in, u, err)
+			}
+		}
+	}
+}
+
+func TestParse_Allocs(t *testing.T) {
+	const s = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	b := []byte("urn:uuid:6ba7b8109dad11d180b400c04fd430c8")
+
+	if n := testing.AllocsPerRun(100, func() { _, _ = Parse(s) }); n != 0 {
+		t.Errorf("Parse allocates %v times, want 0", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { _, _ = ParseBytes(b) }); n != 0 {
+		t.Errorf("ParseBytes allocates %v times, want 0", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { _ = MustParse(s) }); n != 0 {
+		t.Errorf("MustParse allocates %v times, want 0", n)
+	}
+	var u UUID
+	if n := testing.AllocsPerRun(100, func() { _ = u.UnmarshalText(b) }); n != 0 {
+		t.Errorf("UnmarshalText allocates %v times, want 0", n)
+	}
+}
+
+func TestHexSWAR(t *testing.T) {
+	const canonical = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	// Substitute every byte value at every position and compare the SWAR
+	// path against the per-nibble reference decoder.
+	for _, p := range []Parser{{}, StrictParser, {Case: UpperCase}} {
+		for pos := range len(canonical) {
+			for c := range 256 {
+				b := []byte(canonical)
+				b[pos] = byte(c)
+
+				var want UUID
+				ok := true
+				for i, x := range []int{0, 2, 4, 6, 9, 11, 14, 16, 19, 21, 24, 26, 28, 30, 32, 34} {
+					v1, v2 := p.fromHex(b[x]), p.fromHex(b[x+1])
+					if v1|v2 == 255 {
+						ok = false
+						break
+					}
+					want[i] = v1<<4 | v2
+				}
+				for _, x := range []int{8, 13, 18, 23} {
+					ok = ok && b[x] == '-'
+				}
+
+				got, err := p.ParseBytes(b)
+				if ok != (err == nil) || (ok && got != want) {
+					t.Fatalf("%+v.ParseBytes(%q) = %s, %v, want %s, ok=%v", p, b, got, err, want, ok)
+				}
+			}
+		}
+	}
+
+	var buf [36]byte
+	for i := range 256 {
+		var u UUID
+		for j := range u {
+			u[j] = byte(i*31 + j*17)
+		}
+		encodeCanonical(buf[:], u)
+		if string(buf[:]) != u.String() {
+			t.Fatalf("encodeCanonical(%x) = %s, want %s", u[:], buf[:], u.String())
+		}
+	}
+}
+
+func TestParseMany(t *testing.T) {
+	head := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	src := []string{
+		"6ba7b811-9dad-11d1-80b4-00c04fd430c8",
+		"{6ba7b812-9dad-11d1-80b4-00c04fd430c8}",
+		"urn:uuid:6ba7b8139dad11d180b400c04fd430c8",
+	}
+
+	got, err := ParseMany([]UUID{head}, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1+len(src) || got[0] != head {
+		t.Fatalf("ParseMany() = %v, want %s followed by %d UUIDs", got, head, len(src))
+	}
+	for i, s := range src {
+		if want := MustParse(s); got[1+i] != want {
+			t.Errorf("ParseMany()[%d] = %s, want %s", 1+i, got[1+i], want)
+		}
+	}
+
+	bad := []string{src[0], src[1], "6ba7b814-9dad-11d1-80b4-00c04fd430cZ", src[2]}
+	got, err = ParseMany([]UUID{head}, bad)
+	if !errors.Is(err, ErrInvalidChar) {
+		t.Fatalf("ParseMany() error = %v, want %v", err, ErrInvalidChar)
+	}
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Input != bad[2] || !strings.HasPrefix(err.Error(), "element 2: ") {
+		t.Errorf("ParseMany() error = %v, want element 2 with *ParseError", err)
+	}
+	if want := []UUID{head, MustParse(src[0]), MustParse(src[1])}; !slices.Equal(got, want) {
+		t.Errorf("ParseMany() prefix = %v, want %v", got, want)
+	}
+}
+
+func TestEncodeMany(t *testing.T) {
+	src := []UUID{NilUUID, MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"), Max}
+	buf := make([]byte, 36*len(src)+10)
+	got := EncodeMany(buf, src)
+	if len(got) != 36*len(src) {
+		t.Fatalf("len(EncodeMany()) = %d, want %d", len(got), 36*len(src))
+	}
+	for i, u := range src {
+		if slot := string(got[i*36 : i*36+36]); slot != u.String() {
+			t.Errorf("EncodeMany() slot %d = %s, want %s", i, slot, u)
+		}
+	}
+	if got := EncodeMany(nil, nil); len(got) != 0 {
+		t.Errorf("EncodeMany(nil, nil) = %q, want empty", got)
+	}
+}
+
+func TestUUID_Time(t *testing.T) {
+	// Test vectors from RFC 9562, Appendix A.
+	want := time.Date(2022, time.February, 22, 19, 22, 22, 0, time.UTC)
+	cases := []struct {
+		uuid string
+		time time.Time
+		ok   bool
+	}{
+		{"c232ab00-9414-11ec-b3c8-9f6bdeced846", want, true}, // V1
+		{"1ec9414c-232a-6b00-b3c8-9f6bdeced846", want, true}, // V6
+		{"017f22e2-79b0-7cc3-98c4-dc0c0c07398f", want, true}, // V7
+		{"919108f7-52d1-4320-9bac-f847db4148a8", time.Time{}, false},
+		{"00000000-0000-0000-0000-000000000000", time.Time{}, false},
+	}
+	for _, tc := range cases {
+		u := MustParse(tc.uuid)
+		got, ok := u.Time()
+		if ok != tc.ok || !got.Equal(tc.time) {
+			t.Errorf("%s.Time() = %v, %v, want %v, %v", u, got, ok, tc.time, tc.ok)
+		}
+	}
+
+	u := MustParse("c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	if seq, ok := u.ClockSequence(); !ok || seq != 0x33C8 {
+		t.Errorf("ClockSequence() = %#x, %v, want 0x33c8", seq, ok)
+	}
+	if node, ok := u.NodeID(); !ok || node != [6]byte{0x9f, 0x6b, 0xde, 0xce, 0xd8, 0x46} {
+		t.Errorf("NodeID() = %x, %v", node, ok)
+	}
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	v7, _ := NewV7()
+	v4, _ := NewV4()
+	var future UUID
+	binary.BigEndian.PutUint64(future[:8], uint64(time.Now().Add(48*time.Hour).UnixMilli())<<16)
+	future.SetVersion(V7)
+	future.SetVariant(VariantRFC9562)
+
+	cases := []struct {
+		policy Policy
+		u      UUID
+		err    error
+	}{
+		{Policy{}, v7, nil},
+		{Policy{}, v4, nil},
+		{Policy{}, MustParse("c232ab00-9414-11ec-b3c8-9f6bdeced846"), nil},
+		{Policy{}, MustParse("919108f7-52d1-f320-9bac-f847db4148a8"), ErrInvalidVersion},
+		{Policy{}, MustParse("919108f7-52d1-0320-9bac-f847db4148a8"), ErrInvalidVersion},
+		{Policy{}, MustParse("919108f7-52d1-4320-cbac-f847db4148a8"), ErrInvalidVariant},
+		{Policy{}, NilUUID, ErrInvalidVariant},
+		{Policy{AllowNil: true}, NilUUID, nil},
+		{Policy{}, future, ErrFutureTimestamp},
+		{Policy{MaxFuture: -1}, future, nil},
+		{Policy{Versions: Versions(V4, V7)}, v7, nil},
+		{Policy{Versions: Versions(V4, V7)}, MustParse("c232ab00-9414-11ec-b3c8-9f6bdeced846"), ErrInvalidVersion},
+	}
+	for _, tc := range cases {
+		if err := tc.policy.Validate(tc.u); !errors.Is(err, tc.err) {
+			t.Errorf("%+v.Validate(%s) = %v, want %v", tc.policy, tc.u, err, tc.err)
+		}
+	}
+}
+
+func TestInspect(t *testing.T) {
+	ts := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+
+	// Test vectors from RFC 9562, Appendix A and B.
+	v1 := Inspect(MustParse("c232ab00-9414-11ec-b3c8-9f6bdeced846"))
+	if !v1.ValidVersion || v1.Version != V1 || !v1.HasTime || !v1.Time.Equal(ts) ||
+		!v1.HasNode || v1.ClockSeq != 0x33c8 || v1.Node != [6]byte{0x9f, 0x6b, 0xde, 0xce, 0xd8, 0x46} {
+		t.Errorf("Inspect(V1) = %#v", v1)
+	}
+
+	v4 := Inspect(MustParse("919108f7-52d1-4320-9bac-f847db4148a8"))
+	if !v4.ValidVersion || v4.Version != V4 || v4.HasTime || v4.HasNode || v4.RandA != 0 {
+		t.Errorf("Inspect(V4) = %#v", v4)
+	}
+
+	v7 := Inspect(MustParse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f"))
+	if !v7.ValidVersion || v7.Version != V7 || !v7.HasTime || !v7.Time.Equal(ts) || v7.HasNode || v7.RandA != 0xcc3 {
+		t.Errorf("Inspect(V7) = %#v", v7)
+	}
+
+	v8 := Inspect(MustParse("2489e9ad-2ee2-8e00-8ec9-32d5f69181c0"))
+	if !v8.ValidVersion || v8.Version != V8 || v8.HasTime ||
+		v8.CustomA != 0x2489e9ad2ee2 || v8.CustomB != 0xe00 || v8.CustomC != 0x0ec932d5f69181c0 {
+		t.Errorf("Inspect(V8) = %#v", v8)
+	}
+
+	for _, tc := range []struct {
+		u       UUID
+		variant byte
+	}{
+		{NilUUID, VariantNCS},
+		{Max, VariantFuture},
+		{MustParse("6ba7b810-9dad-11d1-c0b4-00c04fd430c8"), VariantMicrosoft},
+	} {
+		info := Inspect(tc.u)
+		if info.Variant != tc.variant || info.ValidVariant || info.ValidVersion || info.HasTime || info.HasNode {
+			t.Errorf("Inspect(%s) = %#v", tc.u, info)
+		}
+	}
+
+	const golden = `UUID:     c232ab00-9414-11ec-b3c8-9f6bdeced846
+Variant:  RFC 9562
+Version:  1 (Gregorian time-based)
+Time:     2022-02-22T19:22:22Z
+ClockSeq: 0x33c8
+Node:     9f:6b:de:ce:d8:46
+`
+	if got := v1.String(); got != golden {
+		t.Errorf("Info.String() =\n%s\nwant\n%s", got, golden)
+	}
+	if got, want := Inspect(Max).String(), "UUID:     ffffffff-ffff-ffff-ffff-ffffffffffff\nSpecial:  max UUID\nVariant:  future (reserved)\n"; got != want {
+		t.Errorf("Info.String() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestV7Range(t *testing.T) {
+	from := time.Now()
+	u, err := defaultGen.NewV7Lazy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lo, hi := V7Range(from, time.Now())
+	if u.Compare(lo) < 0 || u.Compare(hi) > 0 {
+		t.Errorf("%s not in [%s, %s]", u, lo, hi)
+	}
+
+	ts := time.UnixMilli(1645557742000)
+	for _, b := range []UUID{MinV7(ts), MaxV7(ts)} {
+		if err := Validate(b); err != nil {
+			t.Errorf("Validate(%s) = %v", b, err)
+		}
+		if got, _ := b.Time(); !got.Equal(ts) {
+			t.Errorf("%s.Time() = %v, want %v", b, got, ts)
+		}
+	}
+	if MaxV7(ts).Compare(MinV7(ts.Add(time.Millisecond))) >= 0 {
+		t.Error("MaxV7(t) must sort before MinV7(t+1ms)")
+	}
+
+	// Out-of-range times are clamped instead of wrapping around.
+	pre, post := time.Unix(-1, 0), time.UnixMilli(1<<48)
+	if got, want := MinV7(pre), MinV7(time.UnixMilli(0)); got != want {
+		t.Errorf("MinV7(%v) = %s, want %s", pre, got, want)
+	}
+	if got, want := MaxV7(post), MaxV7(time.UnixMilli(1<<48-1)); got != want {
+		t.Errorf("MaxV7(%v) = %s, want %s", post, got, want)
+	}
+	if lo, hi := V7Range(pre, ts); lo.Compare(hi) > 0 || lo.Compare(MinV7(ts)) > 0 {
+		t.Errorf("V7Range(%v, %v) = [%s, %s], want lo <= hi", pre, ts, lo, hi)
+	}
+	if lo, hi := V7Range(ts, post); lo.Compare(hi) > 0 || hi.Compare(MaxV7(ts)) < 0 {
+		t.Errorf("V7Range(%v, %v) = [%s, %s], want lo <= hi", ts, post, lo, hi)
+	}
+}
+
+func TestUUID_Arithmetic(t *testing.T) {
+	if got := Max.Next(); got != NilUUID {
+		t.Errorf("Max.Next() = %s, want %s", got, NilUUID)
+	}
+	if got := NilUUID.Prev(); got != Max {
+		t.Errorf("NilUUID.Prev() = %s, want %s", got, Max)
+	}
+
+	u := FromUint128(1, ^uint64(0))
+	if got, want := u.Next(), FromUint128(2, 0); got != want {
+		t.Errorf("%s.Next() = %s, want %s", u, got, want)
+	}
+	if got := u.Add(10).Sub(10); got != u {
+		t.Errorf("%s.Add(10).Sub(10) = %s", u, got)
+	}
+	if got := u.Xor(u); got != NilUUID {
+		t.Errorf("%s.Xor(itself) = %s, want %s", u, got, NilUUID)
+	}
+
+	for _, v := range []UUID{NilUUID, u, Max} {
+		got, err := FromBigInt(v.BigInt())
+		if err != nil || got != v {
+			t.Errorf("FromBigInt(%s.BigInt()) = %s, %v", v, got, err)
+		}
+	}
+	over := new(big.Int).Add(Max.BigInt(), big.NewInt(1))
+	if _, err := FromBigInt(over); err == nil {
+		t.Error("FromBigInt(2^128) succeeded")
+	}
+}
+
+func TestSplitRange(t *testing.T) {
+	cases := []struct {
+		lo, hi UUID
+		n      int
+		want   int
+	}{
+		{NilUUID, Max, 7, 7},
+		{NilUUID, Max, 2, 2},
+		{NilUUID, Max, 1000, 1000},
+		{FromUint128(0, 1), Max, 3, 3},
+		{FromUint128(0, 5), FromUint128(0, 9), 2, 2},
+		{FromUint128(0, 5), FromUint128(0, 9), 4, 4},
+		{FromUint128(0, 5), FromUint128(0, 9), 10, 5},
+		{FromUint128(0, 0), FromUint128(0, 9), 3, 3},
+		{FromUint128(0, 1<<63), FromUint128(1, 1<<63), 6, 6},
+		{Max, Max, 3, 1},
+	}
+	for _, tc := range cases {
+		rs := SplitRange(tc.lo, tc.hi, tc.n)
+		if len(rs) != tc.want {
+			t.Fatalf("SplitRange(%s, %s, %d) returned %d ranges, want %d", tc.lo, tc.hi, tc.n, len(rs), tc.want)
+		}
+		if rs[0].Lo != tc.lo || rs[len(rs)-1].Hi != tc.hi {
+			t.Errorf("SplitRange(%s, %s, %d) = %v, bounds mismatch", tc.lo, tc.hi, tc.n, rs)
+		}
+		for i := 1; i < len(rs); i++ {
+			if rs[i-1].Hi.Next() != rs[i].Lo || rs[i].Empty() {
+				t.Errorf("SplitRange(%s, %s, %d) = %v, not contiguous", tc.lo, tc.hi, tc.n, rs)
+			}
+		}
+
+		// The range holds total UUIDs: the first total%k ranges get one
+		// more than total/k, so sizes differ by at most one.
+		total := new(big.Int).Sub(tc.hi.BigInt(), tc.lo.BigInt())
+		total.Add(total, big.NewInt(1))
+		k := big.NewInt(int64(len(rs)))
+		size, extra := new(big.Int).DivMod(total, k, new(big.Int))
+		for i, r := range rs {
+			want := new(big.Int).Set(size)
+			if int64(i) < extra.Int64() {
+				want.Add(want, big.NewInt(1))
+			}
+			got := r.Hi.sub128(r.Lo).BigInt()
+			if got.Add(got, big.NewInt(1)); got.Cmp(want) != 0 {
+				t.Errorf("SplitRange(%s, %s, %d)[%d] holds %v UUIDs, want %v", tc.lo, tc.hi, tc.n, i, got, want)
+			}
+		}
+	}
+
+	r := Range{Lo: FromUint128(0, 5), Hi: FromUint128(0, 9)}
+	if got, ok := r.Intersect(Range{Lo: FromUint128(0, 9), Hi: Max}); !ok || got.Lo != r.Hi || got.Hi != r.Hi {
+		t.Errorf("Intersect() = %v, %v", got, ok)
+	}
+	if r.Overlaps(Range{Lo: FromUint128(0, 10), Hi: Max}) || !r.Contains(FromUint128(0, 7)) {
+		t.Error("Overlaps or Contains mismatch")
+	}
+}
+
+func TestShardAndRendezvous(t *testing.T) {
+	r := NewRendezvous("a", "b", "c", "d")
+	owners := make(map[UUID]string)
+	for range 1000 {
+		u, _ := NewV7()
+		if s := u.Shard(10); s < 0 || s >= 10 {
+			t.Fatalf("%s.Shard(10) = %d", u, s)
+		}
+		owners[u] = r.Lookup(u)
+	}
+
+	// Removing a node only moves the keys it owned.
+	r.Remove("d")
+	for u, owner := range owners {
+		if got := r.Lookup(u); owner != "d" && got != owner {
+			t.Errorf("Lookup(%s) moved from %s to %s", u, owner, got)
+		}
+	}
+}
+
+func TestShard_Distribution(t *testing.T) {
+	// V7 IDs generated back to back share their timestamp, so this also
+	// checks that Shard doesn't depend on the clock bits.
+	const keys, n = 11000, 10
+	var counts [n]int
+	moved := 0
+	for range keys {
+		u, _ := NewV7()
+		s10, s11 := u.Shard(n), u.Shard(n+1)
+		counts[s10]++
+		if s11 != s10 {
+			moved++
+			if s11 != n {
+				t.Fatalf("%s moved from shard %d to %d, want %d", u, s10, s11, n)
+			}
+		}
+	}
+
+	// Each shard expects keys/n = 1100 keys (stddev ~31).
+	for i, c := range counts {
+		if c < keys/n*85/100 || c > keys/n*115/100 {
+			t.Errorf("shard %d got %d of %d keys, want about %d", i, c, keys, keys/n)
+		}
+	}
+	// Growing to n+1 shards should move about 1/(n+1) = 1000 keys (stddev ~30).
+	if want := keys / (n + 1); moved < want*85/100 || moved > want*115/100 {
+		t.Errorf("growing %d to %d shards moved %d of %d keys, want about %d", n, n+1, moved, keys, want)
+	}
+}
+
+func TestRadixSort(t *testing.T) {
+	for _, n := range []int{0, 1, 100, 5000} {
+		s := make([]UUID, n)
+		for i := range s {
+			if i%3 == 0 {
+				s[i], _ = NewV4()
+			} else {
+				s[i], _ = NewV7()
+			}
+		}
+		s = append(s, s[:n/2]...) // duplicates
+		want := slices.Clone(s)
+		slices.SortFunc(want, UUID.Compare)
+
+		RadixSort(s)
+		if !IsSorted(s) || !slices.Equal(s, want) {
+			t.Fatalf("RadixSort of %d UUIDs is not sorted", len(s))
+		}
+
+		s = Dedup(s)
+		if len(s) != n {
+			t.Errorf("Dedup() left %d UUIDs, want %d", len(s), n)
+		}
+		for _, u := range s {
+			if i, ok := BinarySearch(s, u); !ok || s[i] != u {
+				t.Fatalf("BinarySearch(%s) = %d, %v", u, i, ok)
+			}
+		}
+	}
+}
+
+func TestSetAndMap(t *testing.T) {
+	ids := make([]UUID, 2000)
+	for i := range ids {
+		ids[i], _ = NewV7()
+	}
+
+	ref := make(map[UUID]int)
+	var m Map[int]
+	var s Set
+	for r := range 10000 {
+		u := ids[r%len(ids)]
+		if r%3 == 2 {
+			delete(ref, u)
+			m.Delete(u)
+			s.Remove(u)
+			continue
+		}
+		ref[u] = r
+		m.Put(u, r)
+		s.Add(u)
+	}
+
+	if m.Len() != len(ref) || s.Len() != len(ref) {
+		t.Fatalf("Len() = %d, %d, want %d", m.Len(), s.Len(), len(ref))
+	}
+	for u, want := range ref {
+		if got, ok := m.Get(u); !ok || got != want || !s.Has(u) {
+			t.Fatalf("Get(%s) = %d, %v, want %d", u, got, ok, want)
+		}
+	}
+	if keys := slices.Collect(s.All()); len(keys) != len(ref) || !IsSorted(keys) {
+		t.Errorf("All() returned %d UUIDs, sorted %v", len(keys), IsSorted(keys))
+	}
+
+	b, _ := s.MarshalBinary()
+	var decoded Set
+	if err := decoded.UnmarshalBinary(b); err != nil || decoded.Len() != s.Len() || decoded.Difference(&s).Len() != 0 {
+		t.Errorf("UnmarshalBinary() = %v, len %d, want %d", err, decoded.Len(), s.Len())
+	}
+
+	a, c := NewSet(ids[:10]...), NewSet(ids[5:15]...)
+	if a.Union(c).Len() != 15 || a.Intersect(c).Len() != 5 || a.Difference(c).Len() != 5 {
+		t.Error("set algebra mismatch")
+	}
+
+	// The table doubles only once the 3/4 load factor is reached.
+	for _, tc := range []struct{ n, size int }{{6, 8}, {7, 16}, {768, 1024}, {769, 2048}, {1536, 2048}} {
+		var s Set
+		for _, u := range ids[:tc.n] {
+			s.Add(u)
+		}
+		var g Set
+		g.Grow(tc.n)
+		if len(s.t.ctrl) != tc.size || len(g.t.ctrl) != tc.size {
+			t.Errorf("%d entries: table size %d, after Grow %d, want %d", tc.n, len(s.t.ctrl), len(g.t.ctrl), tc.size)
+		}
+	}
+}
+
+func TestSet_Churn(t *testing.T) {
+	// Fill the table up to its load factor, then keep replacing entries.
+	// Each rehash allocates new control bytes; there must be few of them.
+	const n, ops = 768, 20000
+	ids := make([]UUID, n+ops)
+	for i := range ids {
+		ids[i], _ = NewV4()
+	}
+	var s Set
+	for _, u := range ids[:n] {
+		s.Add(u)
+	}
+	if len(s.t.ctrl) != 1024 {
+		t.Fatalf("table size %d, want 1024", len(s.t.ctrl))
+	}
+
+	rehashes := 0
+	for i := range ops {
+		ctrl := &s.t.ctrl[0]
+		s.Remove(ids[i])
+		s.Add(ids[n+i])
+		if &s.t.ctrl[0] != ctrl {
+			rehashes++
+		}
+	}
+	if s.Len() != n || !s.Has(ids[ops]) || s.Has(ids[ops-1]) {
+		t.Errorf("Len() = %d after churn, want %d", s.Len(), n)
+	}
+	// A rehash is due at most every len/8 inserts.
+	if limit := ops/(len(s.t.ctrl)/8) + 2; rehashes > limit {
+		t.Errorf("%d Remove+Add pairs at a full table caused %d rehashes, want at most %d", ops, rehashes, limit)
+	}
+}
+
+func TestDeltaEncoding(t *testing.T) {
+	v7 := make([]UUID, 1000)
+	for i := range v7 {
+		v7[i], _ = NewV7()
+	}
+	RadixSort(v7)
+
+	for _, ids := range [][]UUID{
+		nil,
+		{NilUUID, NilUUID, Max},
+		{FromUint128(0, 127), FromUint128(0, 128), FromUint128(1, 0)},
+		v7,
+	} {
+		var buf bytes.Buffer
+		if err := EncodeSorted(&buf, ids); err != nil {
+			t.Fatal(err)
+		}
+		got, err := DecodeSorted(&buf)
+		if err != nil || !slices.Equal(got, ids) {
+			t.Errorf("DecodeSorted() = %d UUIDs, %v, want %d", len(got), err, len(ids))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeSorted(&buf, []UUID{Max, NilUUID}); !errors.Is(err, ErrUnsorted) {
+		t.Errorf("EncodeSorted(unsorted) = %v, want ErrUnsorted", err)
+	}
+
+	buf.Reset()
+	_ = EncodeSorted(&buf, []UUID{Max})
+	if _, err := DecodeSorted(bytes.NewReader(buf.Bytes()[:5])); err != io.ErrUnexpectedEOF {
+		t.Errorf("DecodeSorted(truncated) = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
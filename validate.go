@@ -0,0 +1,113 @@
+package uuid
+
+import (
+	"fmt"
+	"time"
+)
+
+// VersionSet is a set of UUID versions, one bit per version.
+type VersionSet uint16
+
+// Versions returns the set holding the versions vs.
+func Versions(vs ...byte) VersionSet {
+	var s VersionSet
+	for _, v := range vs {
+		s |= 1 << (v & 0x0F)
+	}
+	return s
+}
+
+// Has reports whether v is in s.
+func (s VersionSet) Has(v byte) bool {
+	return s&(1<<(v&0x0F)) != 0
+}
+
+// rfc9562Versions holds the versions defined by RFC-9562.
+var rfc9562Versions = Versions(V1, 2, V3, V4, V5, V6, V7, V8)
+
+// DefaultMaxFuture is how far ahead of the current time a timestamp may be
+// under a Policy that leaves MaxFuture unset.
+const DefaultMaxFuture = time.Hour
+
+// Policy describes which UUIDs are accepted by Validate, for use at API
+// boundaries. The zero Policy accepts every RFC-9562 version whose
+// timestamp, if any, is at most DefaultMaxFuture ahead.
+type Policy struct {
+	// Versions is the set of accepted versions. Zero means every version
+	// defined by RFC-9562 (1 to 8).
+	Versions VersionSet
+
+	// MaxFuture bounds how far ahead of the current time the timestamp of
+	// a V1, V6 or V7 UUID may be. Zero means DefaultMaxFuture; a negative
+	// value disables the check.
+	MaxFuture time.Duration
+
+	// AllowNil and AllowMax accept the nil and max UUIDs, which carry no
+	// RFC-9562 variant or version.
+	AllowNil bool
+	AllowMax bool
+}
+
+// DefaultPolicy is the policy used by Validate and ParseStrict.
+var DefaultPolicy = Policy{}
+
+// Validate checks u against p. It returns an error wrapping
+// ErrInvalidVariant, ErrInvalidVersion or ErrFutureTimestamp.
+func (p Policy) Validate(u UUID) error {
+	switch {
+	case u == NilUUID && p.AllowNil:
+		return nil
+	case u == Max && p.AllowMax:
+		return nil
+	}
+
+	if u.Variant() != VariantRFC9562 {
+		return fmt.Errorf("%w %d in %s", ErrInvalidVariant, u.Variant(), u)
+	}
+
+	versions := p.Versions
+	if versions == 0 {
+		versions = rfc9562Versions
+	}
+	if !versions.Has(u.Version()) {
+		return fmt.Errorf("%w %d in %s", ErrInvalidVersion, u.Version(), u)
+	}
+
+	if p.MaxFuture >= 0 {
+		maxFuture := p.MaxFuture
+		if maxFuture == 0 {
+			maxFuture = DefaultMaxFuture
+		}
+		if t, ok := u.Time(); ok && time.Until(t) > maxFuture {
+			return fmt.Errorf("%w: %s in %s", ErrFutureTimestamp, t.UTC().Format(time.RFC3339), u)
+		}
+	}
+	return nil
+}
+
+// Parse parses s like the package-level Parse and then validates the
+// result against p.
+func (p Policy) Parse(s string) (UUID, error) {
+	u, err := Parse(s)
+	if err != nil {
+		return NilUUID, err
+	}
+	if err := p.Validate(u); err != nil {
+		return NilUUID, err
+	}
+	return u, nil
+}
+
+// Validate checks that u conforms to RFC-9562 under DefaultPolicy: the
+// variant is VariantRFC9562, the version is defined, and the timestamp, if
+// any, is not in the far future.
+func Validate(u UUID) error {
+	return DefaultPolicy.Validate(u)
+}
+
+// ParseStrict is like Parse but also validates the UUID under
+// DefaultPolicy. It governs the UUID's content; to restrict its text form,
+// use StrictParser.
+func ParseStrict(s string) (UUID, error) {
+	return DefaultPolicy.Parse(s)
+}
@@ -0,0 +1,29 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+)
+
+// wrapper is the method set of the defined UUID types that only change how
+// a UUID is stored in the database. Each of them keeps the text and JSON
+// forms of UUID, so that changing a column's Go type doesn't change an API.
+type wrapper interface {
+	fmt.Stringer
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+	json.Marshaler
+	json.Unmarshaler
+	driver.Valuer
+	sql.Scanner
+}
+
+var (
+	_ wrapper = (*GUID)(nil)
+	_ wrapper = (*Binary)(nil)
+	_ wrapper = (*Text)(nil)
+	_ wrapper = (*MySQLSwapped)(nil)
+)